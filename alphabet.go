@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Alphabet is the set of tiles a language is played with. Some
+// languages have tiles with more than one letter on them, like the
+// Spanish CH, LL and RR or the Catalan L·L, which count as a single
+// tile on the board and in words.
+//
+// Everywhere else in this package a tile is a rune: the DAWG's edges,
+// the squares of a Board and the tiles on a Rack. A tile that is a
+// single rune stands for itself, while each multi-letter tile is given
+// a code from Unicode's private use area. Alphabet converts between
+// words as they're written and strings of those tile runes.
+type Alphabet struct {
+	tiles  []string
+	codes  map[string]rune
+	names  map[rune]string
+	maxLen int
+}
+
+// firstMultiTile is the code given to an alphabet's first multi-letter
+// tile; later ones follow it.
+const firstMultiTile = ''
+
+var (
+	English = MustAlphabet(strings.Split(ALPHABET, "")...)
+
+	Spanish = MustAlphabet("A", "B", "C", "CH", "D", "E", "F", "G", "H",
+		"I", "J", "L", "LL", "M", "N", "Ñ", "O", "P", "Q", "R", "RR", "S",
+		"T", "U", "V", "X", "Y", "Z")
+
+	Catalan = MustAlphabet("A", "B", "C", "Ç", "D", "E", "F", "G", "H",
+		"I", "J", "L", "L·L", "M", "N", "NY", "O", "P", "QU", "R", "S",
+		"T", "U", "V", "X", "Z")
+
+	Welsh = MustAlphabet("A", "B", "C", "CH", "D", "DD", "E", "F", "FF",
+		"G", "NG", "H", "I", "J", "L", "LL", "M", "N", "O", "P", "PH", "R",
+		"RH", "S", "T", "TH", "U", "W", "Y")
+)
+
+// NewAlphabet returns an Alphabet of tiles, in order.
+func NewAlphabet(tiles ...string) (*Alphabet, error) {
+	a := &Alphabet{
+		codes: map[string]rune{},
+		names: map[rune]string{},
+	}
+	next := firstMultiTile
+	for _, t := range tiles {
+		if t == "" || strings.ContainsAny(t, "[]") {
+			return nil, fmt.Errorf("invalid tile %q", t)
+		}
+		if _, ok := a.codes[t]; ok {
+			return nil, fmt.Errorf("duplicate tile %q", t)
+		}
+
+		code, size := utf8.DecodeRuneInString(t)
+		if code > maxLetter {
+			return nil, fmt.Errorf("tile %q is outside the basic multilingual plane", t)
+		}
+		if n := utf8.RuneCountInString(t); n > 1 || size != len(t) {
+			code = next
+			next++
+			a.maxLen = max(a.maxLen, n)
+		} else {
+			a.maxLen = max(a.maxLen, 1)
+		}
+		a.tiles = append(a.tiles, t)
+		a.codes[t] = code
+		a.names[code] = t
+	}
+	return a, nil
+}
+
+// MustAlphabet is like NewAlphabet but panics on error.
+func MustAlphabet(tiles ...string) *Alphabet {
+	a, err := NewAlphabet(tiles...)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Letters returns the rune for each tile, in order.
+func (a *Alphabet) Letters() []rune {
+	ret := make([]rune, len(a.tiles))
+	for i, t := range a.tiles {
+		ret[i] = a.codes[t]
+	}
+	return ret
+}
+
+// Encode splits word into tiles and returns their runes. Where tiles
+// overlap the longest is taken, so with Spanish "CHICO" is CH-I-C-O.
+// A tile may also be written in brackets, as in "[CH]ICO", which makes
+// the split explicit where taking the longest tile would be wrong.
+func (a *Alphabet) Encode(word string) (string, error) {
+	return a.encode(word, false)
+}
+
+// EncodeTiles is like Encode, but for tiles played on the board as
+// they're written in GCG files: lower case letters are blanks, played
+// as the upper case letter.
+func (a *Alphabet) EncodeTiles(tiles string) (string, error) {
+	return a.encode(tiles, true)
+}
+
+func (a *Alphabet) encode(word string, blanks bool) (string, error) {
+	var ret strings.Builder
+	for rest := word; rest != ""; {
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return "", fmt.Errorf("unclosed [ in %q", word)
+			}
+			code, ok := a.code(rest[1:end], blanks)
+			if !ok {
+				return "", fmt.Errorf("%q in %q is not a tile", rest[1:end], word)
+			}
+			ret.WriteRune(code)
+			rest = rest[end+1:]
+			continue
+		}
+
+		found := false
+		for n := a.maxLen; n > 0 && !found; n-- {
+			prefix := runePrefix(rest, n)
+			if code, ok := a.code(prefix, blanks); ok {
+				ret.WriteRune(code)
+				rest = rest[len(prefix):]
+				found = true
+			}
+		}
+		if !found {
+			r, _ := utf8.DecodeRuneInString(rest)
+			return "", fmt.Errorf("%q in %q is not a tile", r, word)
+		}
+	}
+	return ret.String(), nil
+}
+
+// code returns the rune for tile t, or if blanks is true and t is the
+// lower case of a tile, that tile as a blank.
+func (a *Alphabet) code(t string, blanks bool) (rune, bool) {
+	if code, ok := a.codes[t]; ok {
+		return code, true
+	}
+	if !blanks || strings.ToLower(t) != t {
+		return 0, false
+	}
+	if code, ok := a.codes[strings.ToUpper(t)]; ok {
+		return Blank(code), true
+	}
+	return 0, false
+}
+
+// MustEncode is like Encode but panics on error.
+func (a *Alphabet) MustEncode(word string) string {
+	ret, err := a.Encode(word)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// Decode returns the word spelled by the tile runes in tiles, with
+// blanks in lower case as EncodeTiles reads them. Runes that aren't
+// tiles of a are left as they are. A tile that taking the longest tile
+// would read differently, like the C of C-H-I-C-O in Spanish, is put
+// in brackets, so that encoding the word gives back the same tiles.
+func (a *Alphabet) Decode(tiles string) string {
+	names := []string{}
+	known := []bool{}
+	for _, r := range tiles {
+		t, ok := a.names[Letter(r)]
+		switch {
+		case !ok:
+			t = string(r)
+		case IsBlank(r):
+			t = strings.ToLower(t)
+		}
+		names = append(names, t)
+		known = append(known, ok)
+	}
+
+	var ret strings.Builder
+	for i, t := range names {
+		if known[i] && a.longest(strings.Join(names[i:min(i+a.maxLen, len(names))], "")) != t {
+			ret.WriteString("[" + t + "]")
+		} else {
+			ret.WriteString(t)
+		}
+	}
+	return ret.String()
+}
+
+// longest returns the longest tile at the start of s, as encoding
+// takes it, with blanks as EncodeTiles reads them.
+func (a *Alphabet) longest(s string) string {
+	for n := a.maxLen; n > 0; n-- {
+		prefix := runePrefix(s, n)
+		if _, ok := a.code(prefix, true); ok {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// Width returns the most letters on any one tile.
+func (a *Alphabet) Width() int {
+	return a.maxLen
+}
+
+// runePrefix returns the first n runes of s, or all of s if it's
+// shorter.
+func runePrefix(s string, n int) string {
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return s[:i]
+}
+
+// Dictionary is a Lexicon whose words are spelled with the tiles of
+// Alphabet. Given one, CrossChecks and the move generator try every
+// tile in the alphabet rather than just A to Z.
+type Dictionary struct {
+	Lexicon
+	Alphabet *Alphabet
+}
+
+func (d Dictionary) Letters() []rune {
+	return d.Alphabet.Letters()
+}
+
+// letters returns the tiles CrossChecks should try with j.
+func letters(j Judge) []rune {
+	if l, ok := j.(interface{ Letters() []rune }); ok {
+		return l.Letters()
+	}
+	return englishLetters
+}
+
+var englishLetters = English.Letters()
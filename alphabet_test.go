@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAlphabet(t *testing.T) {
+	ch := Spanish.MustEncode("CH")
+	ll := Spanish.MustEncode("LL")
+
+	Convey("english is the identity", t, func() {
+		So(English.MustEncode("QUIZ"), ShouldEqual, "QUIZ")
+		So(English.Decode("QUIZ"), ShouldEqual, "QUIZ")
+		So(string(English.Letters()), ShouldEqual, ALPHABET)
+		So(English.Width(), ShouldEqual, 1)
+	})
+
+	Convey("multi-letter tiles are one rune", t, func() {
+		So([]rune(ch), ShouldHaveLength, 1)
+		So(ch, ShouldNotEqual, ll)
+		So(Spanish.MustEncode("Ñ"), ShouldEqual, "Ñ")
+		So(Catalan.MustEncode("L·L"), ShouldHaveLength, len(string(firstMultiTile)))
+		So(Spanish.Width(), ShouldEqual, 2)
+		So(Catalan.Width(), ShouldEqual, 3)
+	})
+
+	Convey("encoding takes the longest tile", t, func() {
+		So(Spanish.MustEncode("CHICO"), ShouldEqual, ch+"ICO")
+		So(Spanish.MustEncode("CALLE"), ShouldEqual, "CA"+ll+"E")
+		So(Spanish.MustEncode("PERRO"), ShouldEqual, "PE"+Spanish.MustEncode("RR")+"O")
+		So([]rune(Catalan.MustEncode("COL·LECCIO")), ShouldHaveLength, 8)
+	})
+
+	Convey("brackets split explicitly", t, func() {
+		So(Spanish.MustEncode("[CH]ICO"), ShouldEqual, ch+"ICO")
+		So(Welsh.MustEncode("NG"), ShouldEqual, Welsh.MustEncode("[NG]"))
+		So(Welsh.MustEncode("N[G]"), ShouldEqual, "NG")
+	})
+
+	Convey("decoding", t, func() {
+		for _, w := range []string{"CHICO", "LLAMA", "PERRO", "NIÑO"} {
+			So(Spanish.Decode(Spanish.MustEncode(w)), ShouldEqual, w)
+		}
+		So(Catalan.Decode(Catalan.MustEncode("COL·LEGA")), ShouldEqual, "COL·LEGA")
+	})
+
+	Convey("decoding brackets tiles that would be read differently", t, func() {
+		So(Spanish.Decode(Spanish.MustEncode("[C]HICO")), ShouldEqual, "[C]HICO")
+		So(Spanish.Decode(Spanish.MustEncode("CHICO")), ShouldEqual, "CHICO")
+		So(Welsh.Decode(Welsh.MustEncode("N[G]")), ShouldEqual, "[N]G")
+		So(Spanish.Decode(Spanish.MustEncode("[L]LA[R]RA")), ShouldEqual, "[L]LA[R]RA")
+
+		// Every word of three tiles comes back as it was.
+		bad := []string{}
+		for _, a := range []*Alphabet{Spanish, Catalan, Welsh} {
+			letters := a.Letters()
+			for _, x := range letters {
+				for _, y := range letters {
+					for _, z := range letters {
+						w := string([]rune{x, y, z})
+						if got, err := a.Encode(a.Decode(w)); err != nil || got != w {
+							bad = append(bad, a.Decode(w))
+						}
+					}
+				}
+			}
+		}
+		So(bad, ShouldBeEmpty)
+
+		// Blanks too, as EncodeTiles reads them.
+		tiles := string([]rune{Blank('C'), Blank('H'), 'I'})
+		So(Spanish.Decode(tiles), ShouldEqual, "[c]hI")
+		got, err := Spanish.EncodeTiles(Spanish.Decode(tiles))
+		So(err, ShouldBeNil)
+		So(got, ShouldEqual, tiles)
+	})
+
+	Convey("errors", t, func() {
+		_, err := Spanish.Encode("KIWI")
+		So(err, ShouldNotBeNil)
+		_, err = Spanish.Encode("[CH")
+		So(err, ShouldNotBeNil)
+		_, err = Spanish.Encode("[K]")
+		So(err, ShouldNotBeNil)
+		_, err = NewAlphabet("A", "B", "A")
+		So(err, ShouldNotBeNil)
+		_, err = NewAlphabet("A", "")
+		So(err, ShouldNotBeNil)
+		_, err = NewAlphabet("[")
+		So(err, ShouldNotBeNil)
+		_, err = NewAlphabet("A", "\U0001F600")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestAlphabetDAWG(t *testing.T) {
+	words := "CHA\nCHICO\nLLAMA\nCALLE\nKIWI\n"
+	d := NewDAWG()
+	rejected, err := LoadWords(strings.NewReader(words), LoadOptions{Alphabet: Spanish}, func(w string) error {
+		d.Add(w)
+		return nil
+	})
+	d.Minimize()
+	ch := []rune(Spanish.MustEncode("CH"))[0]
+
+	Convey("loading", t, func() {
+		So(err, ShouldBeNil)
+		So(rejected, ShouldHaveLength, 1)
+		So(rejected[0].Text, ShouldEqual, "KIWI")
+		So(d.Contains(Spanish.MustEncode("CHICO")), ShouldBeTrue)
+		So(d.Contains("CHICO"), ShouldBeFalse)
+		So(d.Count(), ShouldEqual, 4)
+	})
+
+	Convey("length limits count tiles", t, func() {
+		opts := LoadOptions{Alphabet: Spanish, MaxLength: 4}
+		var got []string
+		LoadWords(strings.NewReader(words), opts, func(w string) error {
+			got = append(got, Spanish.Decode(w))
+			return nil
+		})
+		So(got, ShouldResemble, []string{"CHA", "CHICO", "LLAMA", "CALLE"})
+	})
+
+	Convey("recursive add", t, func() {
+		r := NewDAWG()
+		r.AddRecursive(Spanish.MustEncode("CHICO"))
+		So(r.Contains(Spanish.MustEncode("CHICO")), ShouldBeTrue)
+	})
+
+	Convey("cross checks try every tile", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "A")
+
+		// CH-A down through 7, 7.
+		So(b.CrossChecks(7, 6, Dictionary{d, Spanish}), ShouldResemble, map[rune]bool{ch: true})
+		// A DAWG on its own only gets A to Z tried.
+		So(b.CrossChecks(7, 6, d), ShouldResemble, map[rune]bool{})
+	})
+
+	Convey("placing and generating", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(6, 7, Spanish.MustEncode("CHICO"))
+		So(b.Rows[7][6], ShouldEqual, ch)
+		So(b.Rows[7][7], ShouldEqual, 'I')
+		So(b.Rows[7][9], ShouldEqual, 'O')
+
+		b = NewBoard(StandardLayout)
+		b.PlaceAcross(8, 7, "A")
+		plays := playSet(b.GenerateRowMoves(7, Rack{ch: 1}, Dictionary{d, Spanish}))
+		So(plays, ShouldResemble, map[moveKey]bool{{7, 7, Across, string(ch) + "A"}: true})
+		plays = playSet(b.GenerateRowMoves(7, Rack{ch: 1}, d))
+		So(plays, ShouldBeEmpty)
+
+		g := BuildGADDAG([]string{string(ch) + "A", Spanish.MustEncode("CHICO")})
+		g.Alphabet = Spanish
+		plays = playSet(b.GenerateRowMovesGADDAG(7, Rack{ch: 1}, g))
+		So(plays, ShouldResemble, map[moveKey]bool{{7, 7, Across, string(ch) + "A"}: true})
+	})
+
+	Convey("printing", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(6, 7, Spanish.MustEncode("CHICO"))
+		b.PlaceAcross(6, 8, string(Blank([]rune(Spanish.MustEncode("LL"))[0]))+"A")
+		So(b.Rows[7].Spell(Spanish), ShouldEqual, "      CHICO     ")
+		So(b.Rows[8].Spell(Spanish), ShouldEqual, "      llA       ")
+		So(b.Rows[7].Spell(nil), ShouldEqual, b.Rows[7].String())
+		b.Alphabet = Spanish
+		So(strings.Split(b.String(), "\n")[7], ShouldEqual, "      CHICO     ")
+		So(strings.Split(b.Transpose().String(), "\n")[6], ShouldEqual, "       CHll      ")
+
+		var buf bytes.Buffer
+		b = NewBoard(StandardLayout)
+		b.PlaceAcross(8, 7, "A")
+		b.Tracer = NewTreeTracer(&buf, Spanish)
+		for range b.GenerateRowMoves(7, Rack{ch: 1}, Dictionary{d, Spanish}) {
+		}
+		So(buf.String(), ShouldContainSubstring, "move 7,7 across CHA\n")
+	})
+}
+
+func TestGCGAlphabet(t *testing.T) {
+	Convey("words are encoded", t, func() {
+		e := parseLine(">Ana: ACHIOXZ 8H CHICO +24 24", Spanish)
+		So(e.word, ShouldEqual, Spanish.MustEncode("CHICO"))
+		So(e.across, ShouldBeTrue)
+		So(e.x, ShouldEqual, 7)
+		So(e.y, ShouldEqual, 7)
+	})
+}
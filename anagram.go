@@ -0,0 +1,57 @@
+package main
+
+// Anagram is a word that can be made from the tiles on a rack.
+type Anagram struct {
+	Word string
+	// Blanks holds the index in Word of each letter that has to be
+	// played with a blank.
+	Blanks []int
+}
+
+// Anagrams returns every word in d that uses all of the tiles in ra,
+// in lexical order. Blanks are represented in ra as Empty and are only
+// used for letters the rack doesn't otherwise have.
+func (d *DAWG) Anagrams(ra Rack) []Anagram {
+	ret := []Anagram{}
+	d.anagrams(ra, ra.Count(), nil, nil, &ret)
+	return ret
+}
+
+// Subanagrams returns every word in d that can be made from some or
+// all of the tiles in ra, in lexical order.
+func (d *DAWG) Subanagrams(ra Rack) []Anagram {
+	ret := []Anagram{}
+	d.anagrams(ra, 1, nil, nil, &ret)
+	return ret
+}
+
+// anagrams appends to ret every word below d using at least min more
+// tiles from ra. word and blanks hold what has been played so far.
+func (d *DAWG) anagrams(ra Rack, min int, word []rune, blanks []int, ret *[]Anagram) {
+	if d.Terminal && min <= 0 {
+		*ret = append(*ret, Anagram{
+			Word:   string(word),
+			Blanks: append([]int(nil), blanks...),
+		})
+	}
+	for _, r := range sortedEdges(d) {
+		// Prefer a natural tile over a blank: a blank is just as
+		// good later on.
+		t := r
+		if ra[t] == 0 {
+			t = Empty
+			if ra[t] == 0 {
+				continue
+			}
+			blanks = append(blanks, len(word))
+		}
+		// Not Remove and Add: racks to anagram can have more tiles
+		// than a player's.
+		ra[t]--
+		d.Edge[r].anagrams(ra, min-1, append(word, r), blanks, ret)
+		ra[t]++
+		if t == Empty {
+			blanks = blanks[:len(blanks)-1]
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAnagrams(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range append(gameWords, testWords...) {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	words := func(as []Anagram) []string {
+		ret := []string{}
+		for _, a := range as {
+			ret = append(ret, a.Word)
+		}
+		return ret
+	}
+
+	Convey("anagrams", t, func() {
+		ra := Rack{'E': 1, 'N': 1, 'O': 1, 'T': 1}
+		So(words(d.Anagrams(ra)), ShouldResemble, []string{"NOTE", "TONE"})
+		So(ra, ShouldResemble, Rack{'E': 1, 'N': 1, 'O': 1, 'T': 1})
+
+		So(d.Anagrams(Rack{'Q': 1, 'Z': 1}), ShouldBeEmpty)
+	})
+
+	Convey("racks bigger than a player's", t, func() {
+		ra := Rack{'S': 1, 'L': 1, 'O': 1, 'G': 3, 'I': 1, 'N': 1}
+		So(words(d.Anagrams(ra)), ShouldResemble, []string{"SLOGGING"})
+		So(ra, ShouldResemble, Rack{'S': 1, 'L': 1, 'O': 1, 'G': 3, 'I': 1, 'N': 1})
+
+		ra[Empty] = 1
+		So(words(d.Subanagrams(ra)), ShouldContain, "SLOGGING")
+		So(ra[Empty], ShouldEqual, 1)
+	})
+
+	Convey("subanagrams", t, func() {
+		ra := Rack{'A': 1, 'C': 1, 'R': 1}
+		So(d.Subanagrams(ra), ShouldResemble, []Anagram{
+			{Word: "AR"},
+			{Word: "CAR"},
+		})
+	})
+
+	Convey("blanks", t, func() {
+		ra := Rack{'C': 1, 'R': 1, 'E': 1, Empty: 1}
+		So(d.Anagrams(ra), ShouldResemble, []Anagram{
+			{Word: "CARE", Blanks: []int{1}},
+		})
+
+		Convey("only when needed", func() {
+			ra := Rack{'A': 1, Empty: 1}
+			So(d.Anagrams(ra), ShouldResemble, []Anagram{
+				{Word: "AA", Blanks: []int{1}},
+				{Word: "AB", Blanks: []int{1}},
+				{Word: "AD", Blanks: []int{1}},
+				{Word: "AE", Blanks: []int{1}},
+				{Word: "AG", Blanks: []int{1}},
+				{Word: "AH", Blanks: []int{1}},
+				{Word: "AI", Blanks: []int{1}},
+				{Word: "AL", Blanks: []int{1}},
+				{Word: "AM", Blanks: []int{1}},
+				{Word: "AN", Blanks: []int{1}},
+				{Word: "AR", Blanks: []int{1}},
+				{Word: "AS", Blanks: []int{1}},
+				{Word: "AT", Blanks: []int{1}},
+				{Word: "AW", Blanks: []int{1}},
+				{Word: "AX", Blanks: []int{1}},
+				{Word: "AY", Blanks: []int{1}},
+				{Word: "FA", Blanks: []int{0}},
+				{Word: "HA", Blanks: []int{0}},
+				{Word: "LA", Blanks: []int{0}},
+				{Word: "MA", Blanks: []int{0}},
+				{Word: "NA", Blanks: []int{0}},
+				{Word: "PA", Blanks: []int{0}},
+				{Word: "TA", Blanks: []int{0}},
+				{Word: "YA", Blanks: []int{0}},
+				{Word: "ZA", Blanks: []int{0}},
+			})
+		})
+
+		Convey("two blanks", func() {
+			ra := Rack{Empty: 2}
+			as := d.Anagrams(ra)
+			So(len(as), ShouldEqual, 80)
+			So(as[0], ShouldResemble, Anagram{Word: "AA", Blanks: []int{0, 1}})
+		})
+	})
+}
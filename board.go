@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 )
 
 const (
@@ -12,67 +14,73 @@ const (
 	ALPHABET = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 )
 
-var (
-	TilePoints map[rune]int
-	rootDAWG   *DAWG
-)
-
-func init() {
-	TilePoints = map[rune]int{}
-
-	p := map[string]int{
-		// Blank tiles are worth zero points, so yay zero values :)
-		"EAIONRTLSU": 1,
-		"DG":         2,
-		"BCMP":       3,
-		"FHVWY":      4,
-		"K":          5,
-		"JX":         8,
-		"QZ":         10,
-	}
+// Board is row-major, i.e. Rows[y][x]. Its size and premium squares
+// are given by Layout.
+type Board struct {
+	Layout *Layout
+	Rows   []Row
+	// Alphabet, if set, spells out the tiles on the board when it's
+	// printed, so that tiles of more than one letter show as such.
+	Alphabet *Alphabet
+	// Tracer, if set, is told about each step the move generators
+	// take.
+	Tracer Tracer
+	// Cross, if set, is used by the move generators and scoring in
+	// place of working out cross-checks, cross-scores and anchors
+	// from the board. It must judge words with the same lexicon as
+	// the generators are given.
+	Cross *CrossCache
+	// transposed is set if b is the transpose of Cross's board.
+	transposed bool
+}
 
-	for s, n := range p {
-		for _, r := range s {
-			TilePoints[r] = n
-		}
+// NewBoard returns an empty board with layout l.
+func NewBoard(l *Layout) *Board {
+	b := &Board{Layout: l, Rows: make([]Row, l.Height())}
+	for y := range b.Rows {
+		b.Rows[y] = make(Row, l.Width())
 	}
+	return b
 }
 
-// Board is row-major, i.e. [y][x].
-type Board [15]Row
-
 func (b *Board) String() string {
 	ret := ""
-	for _, row := range b {
-		ret = ret + row.String() + "\n"
+	for _, row := range b.Rows {
+		ret = ret + row.Spell(b.Alphabet) + "\n"
 	}
 	return ret
 }
 
 // Transpose returns a new Board populated by the
-// transposition of b.
+// transposition of b, including its layout.
 func (b *Board) Transpose() *Board {
-	a := &Board{}
-	for x := range b {
-		for y := range b[x] {
-			a[x][y] = b[y][x]
+	a := NewBoard(b.Layout.Transpose())
+	a.Alphabet = b.Alphabet
+	a.Tracer = b.Tracer
+	if b.Cross != nil {
+		a.Cross = b.Cross
+		a.transposed = !b.transposed
+	}
+	for x := range a.Rows {
+		for y := range a.Rows[x] {
+			a.Rows[x][y] = b.Rows[y][x]
 		}
 	}
 	return a
 }
 
 func (b *Board) PlaceAcross(x, y int, word string) {
-	for c, r := range word {
-		// TODO: double check here (or elsewhere)
-		// that if y, c+x is already played that it equals r.
-		if y >= len(b) {
-			panic(fmt.Sprintf("y %d is greater than board len %d", y, len(b)))
+	for c, r := range []rune(word) {
+		// This overwrites whatever is already there; use
+		// ValidatePlay first to check the play is legal.
+		if y >= len(b.Rows) {
+			panic(fmt.Sprintf("y %d is greater than board len %d", y, len(b.Rows)))
 		}
-		if c+x >= len(b[y]) {
-			panic(fmt.Sprintf("x %d + c %d  is greater than board len %d", x, c, len(b[y])))
+		if c+x >= len(b.Rows[y]) {
+			panic(fmt.Sprintf("x %d + c %d  is greater than board len %d", x, c, len(b.Rows[y])))
 		}
 
-		b[y][c+x] = r
+		b.Rows[y][c+x] = r
 	}
 }
 
@@ -83,77 +91,51 @@ func (b *Board) PlaceDown(x, y int, word string) *Board {
 	return b
 }
 
-func (b *Board) ScoreAcross(x, y int, word string) int {
+func (b *Board) ScoreAcross(ts *TileSet, x, y int, word string) int {
 	ret := 0
-	wordMult := 0
+	wordMult := 1
 	newTilesPlayed := 0
 	sidePoints := 0
-	for i, r := range word {
+	for i, r := range []rune(word) {
 		// Discount positions that have already been played.
 		// They count towards the base score, but multipliers
 		// no longer work and we won't check for side points of
 		// other words formed vertically since they've already
 		// been used in previous plays.
-		//fmt.Printf("checking %d, %d: %s\n", x+i, y, string(b[y][x+i]))
-		if b[y][x+i] == '*' {
-			// Blanks/wildcard tiles don't contribute the score.
-			continue
-		}
-		if b[y][x+i] != Empty {
-			ret = ret + TilePoints[r]
+		//fmt.Printf("checking %d, %d: %s\n", x+i, y, string(b.Rows[y][x+i]))
+		if t := b.Rows[y][x+i]; t != Empty {
+			// Blanks already played are worth nothing, whatever
+			// the word says is there.
+			ret = ret + ts.Value(t)
 			//fmt.Printf("%s was already played\n", string(r))
 			continue
 		}
 		newTilesPlayed += 1
-		s := ScrabbleScores.ScoreAt(x+i, y)
-		sp := b.SidePoints(x+i, y, r)
-		if sp > 0 {
-			switch s {
-			case TL:
-				sp += TilePoints[r] * 3
-			case DL:
-				sp += TilePoints[r] * 2
-			default:
-				sp += TilePoints[r]
-			}
-			switch s {
-			case DW:
-				sp *= 2
-			case TW:
-				sp *= 3
-			}
+		s := b.Layout.ScoreAt(x+i, y)
+		letter := ts.Value(r) * s.LetterMultiplier()
+		sp := b.sidePoints(ts, x+i, y, r)
+		if b.touchesDown(x+i, y) {
+			// The new tile's premium counts for the word formed
+			// down through it too.
+			sp = (sp + letter) * s.WordMultiplier()
 		}
 		sidePoints += sp
 
-		switch s {
-		case DW:
-			wordMult += 2
-		case TW:
-			wordMult += 3
-		}
-		switch s {
-		case TL:
-			ret += TilePoints[r] * 3
-		case DL:
-			ret = ret + TilePoints[r]*2
-		default:
-			ret = ret + TilePoints[r]
-		}
-	}
-	if wordMult > 0 {
-		ret = ret * wordMult
+		wordMult *= s.WordMultiplier()
+		ret += letter
 	}
+	ret *= wordMult
 
 	// Bingo bonus:
-	if newTilesPlayed == 7 {
+	if newTilesPlayed == ts.RackSize {
 		//fmt.Printf("bingo\n")
-		ret += 50
+		ret += ts.BingoBonus
 	}
 
 	return ret + sidePoints
 }
 
-func (b *Board) SidePoints(x, y int, r rune) int {
+func (b *Board) SidePoints(ts *TileSet, x, y int, r rune) int {
 	// Check above and below x, y to see if there are tangential words.
 	ret := 0
 	startY := y
@@ -161,30 +143,85 @@ func (b *Board) SidePoints(x, y int, r rune) int {
 
 	// stop when startY hits an empty space or 0
 	for ; startY > 0; startY-- {
-		r := b[startY-1][x]
+		r := b.Rows[startY-1][x]
 		if r == Empty {
 			break
 		}
-		//fmt.Printf("adding %d for %s\n", TilePoints[r], string(r))
-		ret += TilePoints[r]
+		//fmt.Printf("adding %d for %s\n", ts.Points[r], string(r))
+		ret += ts.Value(r)
 	}
 
-	for ; endY < len(b)-1; endY++ {
-		r := b[endY+1][x]
+	for ; endY < len(b.Rows)-1; endY++ {
+		r := b.Rows[endY+1][x]
 		if r == Empty {
 			break
 		}
-		//fmt.Printf("adding %d for %s\n", TilePoints[r], string(r))
-		ret += TilePoints[r]
+		//fmt.Printf("adding %d for %s\n", ts.Points[r], string(r))
+		ret += ts.Value(r)
 	}
 
 	//fmt.Printf("sp, starting with %s: %d\n", string(r), ret)
 	return ret
 }
 
-func (b *Board) ScoreDown(x, y int, word string) int {
+// sidePoints returns SidePoints, from b.Cross if it has the points in
+// ts.
+func (b *Board) sidePoints(ts *TileSet, x, y int, r rune) int {
+	switch {
+	case b.Cross == nil || b.Cross.ts != ts:
+		return b.SidePoints(ts, x, y, r)
+	case b.transposed:
+		return b.Cross.CrossScore(y, x, Down)
+	}
+	return b.Cross.CrossScore(x, y, Across)
+}
+
+// crossCheck is the letters that may go on a square, as the move
+// generators look them up: from the board's CrossCache if it has one,
+// without making a map of them, or else from Board.CrossChecks.
+type crossCheck struct {
+	cache  *CrossCache
+	checks LetterSet
+	set    map[rune]bool
+}
+
+// allows returns true if the tile t may go on the square.
+func (c crossCheck) allows(t rune) bool {
+	if c.cache == nil {
+		return c.set[Letter(t)]
+	}
+	i, ok := c.cache.index[Letter(t)]
+	return ok && c.checks.Has(i)
+}
+
+// letters returns the letters that may go on the square, for Tracers.
+func (c crossCheck) letters() map[rune]bool {
+	if c.cache == nil {
+		return c.set
+	}
+	return c.cache.letterMap(c.checks)
+}
+
+// crossChecks returns CrossChecks, from b.Cross if it's set.
+func (b Board) crossChecks(x, y int, lex Judge) crossCheck {
+	switch {
+	case b.Cross == nil:
+		return crossCheck{set: b.CrossChecks(x, y, lex)}
+	case b.transposed:
+		return crossCheck{cache: b.Cross, checks: b.Cross.CrossChecks(y, x, Down)}
+	}
+	return crossCheck{cache: b.Cross, checks: b.Cross.CrossChecks(x, y, Across)}
+}
+
+// touchesDown returns true if there's a tile directly above or below
+// x, y, so that a tile played there forms a word down the board.
+func (b *Board) touchesDown(x, y int) bool {
+	return (y > 0 && b.Rows[y-1][x] != Empty) || (y < len(b.Rows)-1 && b.Rows[y+1][x] != Empty)
+}
+
+func (b *Board) ScoreDown(ts *TileSet, x, y int, word string) int {
 	b = b.Transpose()
-	return b.ScoreAcross(y, x, word)
+	return b.ScoreAcross(ts, y, x, word)
 }
 
 type Judge interface {
@@ -192,7 +229,9 @@ type Judge interface {
 }
 
 // CrossChecks returns the list of valid runes that may be placed at
-// x, y that will not create a word that j rejects.
+// x, y that will not create a word that j rejects. The runes tried are
+// the tiles of j's Alphabet if it's a Dictionary, or A to Z otherwise.
+// Blanks above or below x, y count as the letter they were played as.
 func (b *Board) CrossChecks(x, y int, j Judge) map[rune]bool {
 	ret := map[rune]bool{}
 	startY := y
@@ -200,13 +239,13 @@ func (b *Board) CrossChecks(x, y int, j Judge) map[rune]bool {
 
 	// stop when startY hits an empty space or 0
 	for ; startY > 0; startY-- {
-		if b[startY-1][x] == Empty {
+		if b.Rows[startY-1][x] == Empty {
 			break
 		}
 	}
 
-	for ; endY < len(b)-1; endY++ {
-		if b[endY+1][x] == Empty {
+	for ; endY < len(b.Rows)-1; endY++ {
+		if b.Rows[endY+1][x] == Empty {
 			break
 		}
 	}
@@ -214,7 +253,7 @@ func (b *Board) CrossChecks(x, y int, j Judge) map[rune]bool {
 	// If start == end, then this square has empty above and below.
 	// So it can be any rune.
 	if startY == endY {
-		for _, r := range ALPHABET {
+		for _, r := range letters(j) {
 			ret[r] = true
 		}
 		return ret
@@ -225,11 +264,11 @@ func (b *Board) CrossChecks(x, y int, j Judge) map[rune]bool {
 	w := []rune{}
 
 	for i := startY; i <= endY; i++ {
-		w = append(w, b[i][x])
+		w = append(w, Letter(b.Rows[i][x]))
 	}
 
 	// Now for the Judgement!
-	for _, r := range ALPHABET {
+	for _, r := range letters(j) {
 		w[y-startY] = r
 		if j.Contains(string(w)) {
 			ret[r] = true
@@ -239,14 +278,35 @@ func (b *Board) CrossChecks(x, y int, j Judge) map[rune]bool {
 	return ret
 }
 
-type Row [15]rune
+type Row []rune
 
+// String renders r with blanks as the lower case of the letter they
+// were played as. Tiles of more than one letter need an Alphabet to
+// be spelled out; see Spell.
 func (r Row) String() string {
+	return r.Spell(nil)
+}
+
+// Spell renders r like String, but spells out its tiles with the
+// letters they have in a. a may be nil, for tiles that are single
+// letters.
+func (r Row) Spell(a *Alphabet) string {
 	ret := ""
 	for _, t := range r {
-		if t == Empty {
+		name, ok := "", false
+		if a != nil {
+			name, ok = a.names[Letter(t)]
+		}
+		switch {
+		case t == Empty:
 			ret = ret + " "
-		} else {
+		case ok && IsBlank(t):
+			ret = ret + strings.ToLower(name)
+		case ok:
+			ret = ret + name
+		case IsBlank(t):
+			ret = ret + string(unicode.ToLower(Letter(t)))
+		default:
 			ret = ret + string(t)
 		}
 	}
@@ -255,91 +315,162 @@ func (r Row) String() string {
 
 // Anchors returns the positions of possible anchor squares in the row.
 // An anchor is a square that is vacant and has a played character to
-// the right of it.
+// the left or right of it. Board.Anchors also counts tiles above and
+// below.
 func (r Row) Anchors() []int {
 	ret := []int{}
-	if r[0] != Empty {
-		ret = append(ret, 0)
-	}
 	for i, v := range r {
 		if v != Empty {
 			continue
 		}
 
-		if i < len(r)-1 && r[i+1] != Empty {
+		if (i > 0 && r[i-1] != Empty) || (i < len(r)-1 && r[i+1] != Empty) {
 			ret = append(ret, i)
 		}
 	}
 	return ret
 }
 
-type Play struct {
-	x, y int
-	word string
+// Anchors returns the anchor squares in row y: the empty squares next
+// to a tile in any direction, one of which every play across the row
+// must cover. On an empty board the only anchor is the start square.
+func (b *Board) Anchors(y int) []int {
+	if b.Cross != nil {
+		return b.Cross.anchorsIn(y, b.transposed)
+	}
+	ret := []int{}
+	if b.isEmpty() {
+		if sx, sy := b.Layout.Start(); sy == y {
+			ret = append(ret, sx)
+		}
+		return ret
+	}
+	for x := range b.Rows[y] {
+		if b.isAnchor(x, y) {
+			ret = append(ret, x)
+		}
+	}
+	return ret
+}
+
+func (b *Board) isAnchor(x, y int) bool {
+	row := b.Rows[y]
+	if row[x] != Empty {
+		return false
+	}
+	return (x > 0 && row[x-1] != Empty) || (x < len(row)-1 && row[x+1] != Empty) || b.touchesDown(x, y)
 }
 
-// More or less literal implementation of pseudocode from the 1988 ACM paper:
-func (b Board) LeftPart(x, y int, partialWord string, node *DAWG, limit int, ra Rack, plays chan Play) {
-	fmt.Printf("left part %d, %d %q\n", x, y, partialWord)
-	// The left part may consist of tiles already on the board.
+// More or less literal implementation of pseudocode from the 1988 ACM paper.
+// x is the anchor square; the left part is placed on the empty squares
+// to its left and may be at most limit tiles long. lex is used for
+// cross-checks.
+func (b Board) LeftPart(x, y int, partialWord string, node Node, lex Lexicon, limit int, ra Rack, plays chan Move) {
+	if b.Tracer != nil {
+		b.Tracer.Extend(x, y, partialWord)
+	}
 
-	b.ExtendRight(x, y, partialWord, node, ra, plays)
+	// Unlike in the paper, the squares of the left part may have tiles
+	// above or below them, so they need cross-checking too.
+	left := []rune(partialWord)
+	fits := true
+	for i, r := range left {
+		crossChecks := b.crossChecks(x-len(left)+i, y, lex)
+		if b.Tracer != nil {
+			b.Tracer.CrossChecks(x-len(left)+i, y, crossChecks.letters())
+		}
+		if !crossChecks.allows(r) {
+			fits = false
+			break
+		}
+	}
+	if fits {
+		b.ExtendRight(x, y, x, partialWord, node, lex, ra, plays)
+	}
 	if limit > 0 {
-		for r, nextNode := range node.Edge {
-			if ra[r] > 0 {
-				ra.Remove(r)
-				b.LeftPart(x, y, partialWord+string(r), nextNode, limit-1, ra, plays)
-				ra.Add(r)
+		for r, nextNode := range node.Edges() {
+			for _, t := range ra.playable(r) {
+				ra.Remove(t)
+				b.LeftPart(x, y, partialWord+string(t), nextNode, lex, limit-1, ra, plays)
+				ra.Add(t)
 			}
 		}
 	}
 }
 
-func (b Board) ExtendRight(x, y int, partialWord string, node *DAWG, ra Rack, plays chan Play) {
-	fmt.Printf("extend right: %d, %d: %v\n", x, y, partialWord)
-	if b[y][x] == Empty {
-		fmt.Printf("%d, %d is empty\n", x, y)
-		if node.Terminal {
-			// Send this on a channel?
-			fmt.Printf("found a word: %q\n", partialWord)
-			LegalWord(partialWord)
-			plays <- Play{x, y, partialWord}
+// ExtendRight extends partialWord rightwards from x, y. A play is only
+// legal once it has covered the anchor square.
+func (b Board) ExtendRight(x, y, anchor int, partialWord string, node Node, lex Lexicon, ra Rack, plays chan Move) {
+	if b.Tracer != nil {
+		b.Tracer.Extend(x, y, partialWord)
+	}
+	if x >= len(b.Rows[y]) {
+		// Ran off the edge of the board.
+		if node.IsTerminal() && x > anchor {
+			b.emit(plays, b.rowMove(x, y, partialWord, ra))
+		}
+		return
+	}
+	if b.Rows[y][x] == Empty {
+		if node.IsTerminal() && x > anchor {
+			b.emit(plays, b.rowMove(x, y, partialWord, ra))
 		}
-		crossChecks := b.CrossChecks(x, y, rootDAWG)
-		fmt.Printf("cross checks: %#v\n", crossChecks)
-		for r, nextNode := range node.Edge {
-			fmt.Printf("checking next node %q\n", r)
-			if ra[r] > 0 && crossChecks[r] {
-				fmt.Printf("%q is in rack, and in cross checks\n", r)
-				ra.Remove(r)
-				b.ExtendRight(x+1, y, partialWord+string(r), nextNode, ra, plays)
-				ra.Add(r)
+		crossChecks := b.crossChecks(x, y, lex)
+		if b.Tracer != nil {
+			b.Tracer.CrossChecks(x, y, crossChecks.letters())
+		}
+		for r, nextNode := range node.Edges() {
+			if !crossChecks.allows(r) {
+				continue
+			}
+			for _, t := range ra.playable(r) {
+				ra.Remove(t)
+				b.ExtendRight(x+1, y, anchor, partialWord+string(t), nextNode, lex, ra, plays)
+				ra.Add(t)
 			}
 		}
 	} else {
-		l := b[y][x]
-		fmt.Printf("%d, %d is NOT empty: %q\n", x, y, l)
-		if node.Edge[l] != nil {
-			nextNode := node.Edge[l]
-			b.ExtendRight(x+1, y, partialWord+string(l), nextNode, ra, plays)
+		l := b.Rows[y][x]
+		if nextNode := node.Next(Letter(l)); nextNode != nil {
+			b.ExtendRight(x+1, y, anchor, partialWord+string(l), nextNode, lex, ra, plays)
 		}
 	}
 }
 
-func LegalWord(s string) {
-	fmt.Printf("legal word: %q\n", s)
+// emit sends m on plays, telling b's Tracer about it first.
+func (b Board) emit(plays chan Move, m Move) {
+	if b.Tracer != nil {
+		b.Tracer.Move(m)
+	}
+	plays <- m
 }
 
-func (b Board) GenerateRowMoves(y int, ra Rack, rootNode *DAWG) chan Play {
-	ret := make(chan Play)
-	row := b[y]
-	anchors := row.Anchors()
-	fmt.Printf("anchors for %d:  %#v\n", row, anchors)
+// GenerateRowMoves finds the plays across row y using the tiles on ra.
+// The Moves sent have their Word, Tiles and Leave filled in, but not
+// Score or Words; see GenerateMoves.
+func (b Board) GenerateRowMoves(y int, ra Rack, lex Lexicon) chan Move {
+	ret := make(chan Move)
+	anchors := b.Anchors(y)
 	go func() {
 		for _, x := range anchors {
-			fmt.Printf("checking anchor at %d\n", x)
-			limit := row.LeftMax(x)
-			b.LeftPart(x, y, "", rootNode, limit, ra, ret)
+			if b.Tracer != nil {
+				b.Tracer.Anchor(x, y)
+			}
+			left, limit := b.anchorLeft(x, y)
+			if left != "" {
+				// The left part is already on the board.
+				node := lex.Root()
+				for _, r := range left {
+					if node = node.Next(Letter(r)); node == nil {
+						break
+					}
+				}
+				if node != nil {
+					b.ExtendRight(x, y, x, left, node, lex, ra, ret)
+				}
+				continue
+			}
+			b.LeftPart(x, y, "", lex.Root(), lex, limit, ra, ret)
 		}
 		close(ret)
 	}()
@@ -355,6 +486,26 @@ func (r Row) LeftMax(x int) int {
 	return ret
 }
 
+// anchorLeft returns either the tiles already on the board immediately
+// to the left of anchor x, or the number of tiles a left part from the
+// rack may take up. A left part stops short of any anchor further left,
+// so that every play is only found from the leftmost anchor it covers.
+func (b *Board) anchorLeft(x, y int) (left string, limit int) {
+	row := b.Rows[y]
+	if x > 0 && row[x-1] != Empty {
+		s := x
+		for s > 0 && row[s-1] != Empty {
+			s--
+		}
+		return string(row[s:x]), 0
+	}
+
+	for i := x - 1; i >= 0 && !b.isAnchor(i, y); i-- {
+		limit++
+	}
+	return "", limit
+}
+
 type Rack map[rune]int
 
 func (r Rack) Count() int {
@@ -365,15 +516,24 @@ func (r Rack) Count() int {
 	return n
 }
 
+// Add puts t back on r. A blank goes back as Empty, whatever letter
+// it was played as.
 func (r Rack) Add(t rune) {
 	if r.Count() > 6 {
 		panic("can't add more tiles to rack: " + string(t))
 	}
+	if IsBlank(t) {
+		t = Empty
+	}
 
 	r[t]++
 }
 
+// Remove takes t off r. A blank is taken off as Empty.
 func (r Rack) Remove(t rune) {
+	if IsBlank(t) {
+		t = Empty
+	}
 	if r[t] <= 0 {
 		panic("can't remove tile from rack: " + string(t))
 	}
@@ -381,26 +541,26 @@ func (r Rack) Remove(t rune) {
 	r[t]--
 }
 
-var (
-	TileCounts = map[string]int{
-		"KJQXZ":       1,
-		"BCMPFHVWY":   2,
-		"G":           3,
-		"DLSU":        4,
-		"NRT":         6,
-		"O":           8,
-		"AI":          9,
-		"E":           12,
-		string(Empty): 2,
+// playable returns the tiles on r that can be played as letter l: l
+// itself, and a blank played as l.
+func (r Rack) playable(l rune) []rune {
+	ret := []rune{}
+	if r[l] > 0 {
+		ret = append(ret, l)
 	}
-)
+	if r[Empty] > 0 {
+		ret = append(ret, Blank(l))
+	}
+	return ret
+}
 
 type Sack map[rune]int
 
-func NewSack() Sack {
+// NewSack returns a full sack of the tiles in ts.
+func NewSack(ts *TileSet) Sack {
 	ret := Sack{}
-	for s, c := range TileCounts {
-		for _, r := range s {
+	for r, c := range ts.Counts {
+		if c > 0 {
 			ret[r] = c
 		}
 	}
@@ -435,32 +595,36 @@ const (
 	TL
 	DW
 	TW
+	QL
+	QW
 )
 
-// Row major, so it's [y][x].
-type boardScores [8][8]ScoreType
-
-var (
-	ScrabbleScores = boardScores{
-		{TW, None, None, DL, None, None, None, TW},
-		{None, DW, None, None, None, TL, None, None},
-		{None, None, DW, None, None, None, DL, None},
-		{DL, None, None, DW, None, None, None, DL},
-		{None, None, None, None, DW, None, None, None},
-		{None, TL, None, None, None, TL, None, None},
-		{None, None, DL, None, None, None, DL, None},
-		{TW, None, None, DL, None, None, None, DW},
-	}
-)
-
-func (b boardScores) ScoreAt(x, y int) ScoreType {
-	// Symmetric adjustments if x or y > 7 to simplify checks below.
-	if x > 7 {
-		x = 14 - x
+// LetterMultiplier returns what a tile newly played on a square of
+// type s has its points multiplied by.
+func (s ScoreType) LetterMultiplier() int {
+	switch s {
+	case DL:
+		return 2
+	case TL:
+		return 3
+	case QL:
+		return 4
 	}
+	return 1
+}
 
-	if y > 7 {
-		y = 14 - y
+// WordMultiplier returns what a word covering a newly played tile on a
+// square of type s has its score multiplied by. Multipliers from
+// several squares multiply together, so a word across two triple word
+// squares scores nine times.
+func (s ScoreType) WordMultiplier() int {
+	switch s {
+	case DW:
+		return 2
+	case TW:
+		return 3
+	case QW:
+		return 4
 	}
-	return b[y][x]
+	return 1
 }
@@ -8,10 +8,10 @@ import (
 
 func TestPlace(t *testing.T) {
 	Convey("top left", t, func() {
-		b := &Board{}
+		b := NewBoard(StandardLayout)
 		b.PlaceAcross(0, 0, "DOGEATE")
 		//		Printf("across:\n%s\n", b)
-		b = &Board{}
+		b = NewBoard(StandardLayout)
 		b = b.PlaceDown(0, 0, "DOGEATE")
 		//		Printf("down:\n%s\n", b)
 	})
@@ -19,23 +19,23 @@ func TestPlace(t *testing.T) {
 
 func TestScoreAt(t *testing.T) {
 	Convey("spot checks", t, func() {
-		So(ScrabbleScores.ScoreAt(0, 0), ShouldEqual, TW)
-		So(ScrabbleScores.ScoreAt(1, 1), ShouldEqual, DW)
-		So(ScrabbleScores.ScoreAt(2, 2), ShouldEqual, DW)
-		So(ScrabbleScores.ScoreAt(3, 3), ShouldEqual, DW)
-		So(ScrabbleScores.ScoreAt(4, 4), ShouldEqual, DW)
-		So(ScrabbleScores.ScoreAt(5, 5), ShouldEqual, TL)
-		So(ScrabbleScores.ScoreAt(6, 6), ShouldEqual, DL)
-		So(ScrabbleScores.ScoreAt(7, 7), ShouldEqual, DW)
-		So(ScrabbleScores.ScoreAt(0, 3), ShouldEqual, DL)
-		So(ScrabbleScores.ScoreAt(3, 0), ShouldEqual, DL)
+		So(StandardLayout.ScoreAt(0, 0), ShouldEqual, TW)
+		So(StandardLayout.ScoreAt(1, 1), ShouldEqual, DW)
+		So(StandardLayout.ScoreAt(2, 2), ShouldEqual, DW)
+		So(StandardLayout.ScoreAt(3, 3), ShouldEqual, DW)
+		So(StandardLayout.ScoreAt(4, 4), ShouldEqual, DW)
+		So(StandardLayout.ScoreAt(5, 5), ShouldEqual, TL)
+		So(StandardLayout.ScoreAt(6, 6), ShouldEqual, DL)
+		So(StandardLayout.ScoreAt(7, 7), ShouldEqual, DW)
+		So(StandardLayout.ScoreAt(0, 3), ShouldEqual, DL)
+		So(StandardLayout.ScoreAt(3, 0), ShouldEqual, DL)
 	})
 
 	Convey("symmetry", t, func() {
 		for x := 0; x < 15; x++ {
 			for y := 0; y < 15; y++ {
 				//Convey(fmt.Sprintf("%d, %d", x, y), func() {
-				So(ScrabbleScores.ScoreAt(x, y), ShouldEqual, ScrabbleScores.ScoreAt(y, x))
+				So(StandardLayout.ScoreAt(x, y), ShouldEqual, StandardLayout.ScoreAt(y, x))
 				//})
 			}
 		}
@@ -44,28 +44,28 @@ func TestScoreAt(t *testing.T) {
 
 func TestScoreAcross(t *testing.T) {
 	Convey("spot checks", t, func() {
-		var b Board
-		So(b.ScoreAcross(0, 0, "OH"), ShouldEqual, 15)
-		So(b.ScoreAcross(3, 7, "QUANT"), ShouldEqual, 48)
+		b := NewBoard(StandardLayout)
+		So(b.ScoreAcross(EnglishTiles, 0, 0, "OH"), ShouldEqual, 15)
+		So(b.ScoreAcross(EnglishTiles, 3, 7, "QUANT"), ShouldEqual, 48)
 	})
 }
 
 func TestPlaceAcross(t *testing.T) {
 	Convey("basic", t, func() {
-		var b Board
+		b := NewBoard(StandardLayout)
 		b.PlaceAcross(0, 0, "WHEAT")
-		So(b[0][0], ShouldEqual, 'W')
-		So(b[0][1], ShouldEqual, 'H')
-		So(b[0][2], ShouldEqual, 'E')
-		So(b[0][3], ShouldEqual, 'A')
-		So(b[0][4], ShouldEqual, 'T')
+		So(b.Rows[0][0], ShouldEqual, 'W')
+		So(b.Rows[0][1], ShouldEqual, 'H')
+		So(b.Rows[0][2], ShouldEqual, 'E')
+		So(b.Rows[0][3], ShouldEqual, 'A')
+		So(b.Rows[0][4], ShouldEqual, 'T')
 
 		b.PlaceAcross(7, 4, "WHEAT")
-		So(b[4][7], ShouldEqual, 'W')
-		So(b[4][8], ShouldEqual, 'H')
-		So(b[4][9], ShouldEqual, 'E')
-		So(b[4][10], ShouldEqual, 'A')
-		So(b[4][11], ShouldEqual, 'T')
+		So(b.Rows[4][7], ShouldEqual, 'W')
+		So(b.Rows[4][8], ShouldEqual, 'H')
+		So(b.Rows[4][9], ShouldEqual, 'E')
+		So(b.Rows[4][10], ShouldEqual, 'A')
+		So(b.Rows[4][11], ShouldEqual, 'T')
 	})
 }
 
@@ -82,19 +82,19 @@ func TestCrossChecks(t *testing.T) {
 	}
 
 	Convey("empty", t, func() {
-		var b Board
+		b := NewBoard(StandardLayout)
 		j := testJudge{}
 		// If there are no letters on the board, there are no conflicts.
-		for y, _ := range b {
-			for x, _ := range b[y] {
+		for y := range b.Rows {
+			for x := range b.Rows[y] {
 				So(b.CrossChecks(x, y, j), ShouldResemble, allLetters)
 			}
 		}
 	})
 
 	Convey("some words played", t, func() {
-		var b Board
-		b[7][7] = 'A'
+		b := NewBoard(StandardLayout)
+		b.Rows[7][7] = 'A'
 		j := testJudge{}
 
 		// To the left and right
@@ -114,46 +114,60 @@ func TestCrossChecks(t *testing.T) {
 
 func TestAnchors(t *testing.T) {
 	Convey("basic", t, func() {
-		var r Row
+		r := make(Row, 15)
 		So(len(r.Anchors()), ShouldEqual, 0)
 		r[4] = 'Q'
-		So(r.Anchors(), ShouldResemble, []int{3})
+		So(r.Anchors(), ShouldResemble, []int{3, 5})
 		r[5] = 'I'
-		So(r.Anchors(), ShouldResemble, []int{3})
-		r[7] = 'K'
 		So(r.Anchors(), ShouldResemble, []int{3, 6})
+		r[7] = 'K'
+		So(r.Anchors(), ShouldResemble, []int{3, 6, 8})
+		r[0] = 'A'
+		So(r.Anchors(), ShouldResemble, []int{1, 3, 6, 8})
 
 	})
+
+	Convey("board", t, func() {
+		b := NewBoard(StandardLayout)
+		So(b.Anchors(7), ShouldResemble, []int{7})
+		So(b.Anchors(6), ShouldBeEmpty)
+
+		b.PlaceAcross(7, 7, "QI")
+		So(b.Anchors(7), ShouldResemble, []int{6, 9})
+		So(b.Anchors(6), ShouldResemble, []int{7, 8})
+		So(b.Anchors(8), ShouldResemble, []int{7, 8})
+		So(b.Anchors(9), ShouldBeEmpty)
+	})
 }
 
 func TestTranspose(t *testing.T) {
 	Convey("basic", t, func() {
-		b := &Board{}
+		b := NewBoard(StandardLayout)
 		a := b.Transpose()
 		So(b, ShouldResemble, a)
 
-		b[0][0] = 'c'
+		b.Rows[0][0] = 'c'
 		a = b.Transpose()
-		So(b[0][0], ShouldEqual, 'c')
-		So(a[0][0], ShouldEqual, 'c')
+		So(b.Rows[0][0], ShouldEqual, 'c')
+		So(a.Rows[0][0], ShouldEqual, 'c')
 
-		b[0][1] = 'd'
+		b.Rows[0][1] = 'd'
 		a = b.Transpose()
-		So(b[0][1], ShouldEqual, 'd')
-		So(a[1][0], ShouldEqual, 'd')
+		So(b.Rows[0][1], ShouldEqual, 'd')
+		So(a.Rows[1][0], ShouldEqual, 'd')
 	})
 }
 
 func TestSack(t *testing.T) {
 	Convey("basic", t, func() {
-		s := NewSack()
+		s := NewSack(EnglishTiles)
 		So(len(s), ShouldEqual, 27)
 
 		Convey("draw", func() {
 			sum := 0
 			for i := 0; i < 100; i++ {
 				t := s.Draw()
-				sum += TilePoints[t]
+				sum += EnglishTiles.Points[t]
 			}
 			So(sum, ShouldEqual, 187)
 		})
@@ -162,8 +176,8 @@ func TestSack(t *testing.T) {
 
 func TestPlaysAndScoring(t *testing.T) {
 	Convey("spot check ZED+INCUDIT", t, func() {
-		b := &Board{}
-		So(b.ScoreAcross(6, 7, "ZED"), ShouldEqual, 26)
+		b := NewBoard(StandardLayout)
+		So(b.ScoreAcross(EnglishTiles, 6, 7, "ZED"), ShouldEqual, 26)
 		b.PlaceAcross(6, 7, "ZED")
 		// ID (vertically) should be worth I*2 + D or 2 + 2: 4.
 		// INCUDIT across should be I * 2 + N + C + U + 2*D + I + T: 2 + 1 + 3 + 1 + 4 + 1 + 1, 11.
@@ -172,22 +186,22 @@ func TestPlaysAndScoring(t *testing.T) {
 		// I think the gcg file is wrong.
 		// TODO: find out if this is the case.
 		// Left a comment at http://www.cross-tables.com/annotated.php?a=248#6#
-		// So(b.ScoreAcross(8, 6, "INCUDIT"), ShouldEqual, 71)
+		// So(b.ScoreAcross(EnglishTiles, 8, 6, "INCUDIT"), ShouldEqual, 71)
 	})
 
 	Convey("guy vs mac", t, func() {
-		b := &Board{}
+		b := NewBoard(StandardLayout)
 		guy, mac := 0, 0
 
 		playAcross := func(score *int, x, y int, word string) {
-			*score += b.ScoreAcross(x, y, word)
+			*score += b.ScoreAcross(EnglishTiles, x, y, word)
 			b.PlaceAcross(x, y, word)
 			//	Printf("guy: %d, mac: %d\n", guy, mac)
 			//	Printf("board:\n %s", b)
 		}
 
 		playDown := func(score *int, x, y int, word string) {
-			*score += b.ScoreDown(x, y, word)
+			*score += b.ScoreDown(EnglishTiles, x, y, word)
 			b = b.PlaceDown(x, y, word)
 			//	Printf("guy: %d, mac: %d\n", guy, mac)
 			//		Printf("board:\n %s", b)
@@ -199,7 +213,7 @@ func TestPlaysAndScoring(t *testing.T) {
 		playAcross(&mac, 11, 8, "AJEE")
 		So(mac, ShouldEqual, 25)
 
-		playAcross(&guy, 1, 8, "OUT*REW")
+		playAcross(&guy, 1, 8, "OUT"+string(Blank('G'))+"REW")
 		So(guy, ShouldEqual, 98)
 
 		playDown(&mac, 14, 2, "HYALINE")
@@ -220,7 +234,7 @@ func TestPlaysAndScoring(t *testing.T) {
 		playDown(&guy, 14, 11, "ZEDS")
 		So(guy, ShouldEqual, 184)
 
-		playDown(&mac, 4, 4, "SLOGGI*G")
+		playDown(&mac, 4, 4, "SLOGGI"+string(Blank('N'))+"G")
 		So(mac, ShouldEqual, 254)
 
 		playAcross(&guy, 1, 5, "YIELD")
@@ -249,11 +263,11 @@ func TestPlaysAndScoring(t *testing.T) {
 	})
 }
 
-func BenchmarkScrabbleScoresScoreAt(b *testing.B) {
+func BenchmarkLayoutScoreAt(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for x := 0; x < 15; x++ {
 			for y := 0; y < 15; y++ {
-				_ = ScrabbleScores.ScoreAt(x, y)
+				_ = StandardLayout.ScoreAt(x, y)
 			}
 		}
 	}
@@ -261,18 +275,18 @@ func BenchmarkScrabbleScoresScoreAt(b *testing.B) {
 
 func TestPlaysAndScoringFiles(t *testing.T) {
 	Convey("from file", t, func() {
-		b := &Board{}
+		b := NewBoard(StandardLayout)
 
 		playAcross := func(score *int, x, y int, word string) {
-			*score += b.ScoreAcross(x, y, word)
+			*score += b.ScoreAcross(EnglishTiles, x, y, word)
 			b.PlaceAcross(x, y, word)
 		}
 
 		playDown := func(score *int, x, y int, word string) {
-			*score += b.ScoreDown(x, y, word)
+			*score += b.ScoreDown(EnglishTiles, x, y, word)
 			b = b.PlaceDown(x, y, word)
 		}
-		events := parseFile("1993_wsc_f4_wapnick_nyman.gcg.txt")
+		events := parseFile("1993_wsc_f4_wapnick_nyman.gcg.txt", English)
 		So(events, ShouldNotBeNil)
 
 		scores := map[string]int{}
@@ -300,7 +314,7 @@ func TestPlaysAndScoringFiles(t *testing.T) {
 
 func TestGenerateRowMoves(t *testing.T) {
 	Convey("empty", t, func() {
-		b := &Board{}
+		b := NewBoard(StandardLayout)
 		r := Rack{}
 		dict := &DAWG{}
 		plays := b.GenerateRowMoves(0, r, dict)
@@ -310,7 +324,7 @@ func TestGenerateRowMoves(t *testing.T) {
 	})
 
 	Convey("populated", t, func() {
-		b := &Board{}
+		b := NewBoard(StandardLayout)
 		r := Rack{'F': 1, 'O': 2, 'D': 1, 'L': 1}
 		dict := NewDAWG()
 
@@ -319,13 +333,13 @@ func TestGenerateRowMoves(t *testing.T) {
 		dict.Add("FOOL")
 		dict.Add("FOOD")
 		b.PlaceAcross(0, 0, "F")
-		anchors := b[0].Anchors()
+		anchors := b.Rows[0].Anchors()
 		So(anchors, ShouldNotBeEmpty)
 		Printf("anchors: %#v\n", anchors)
-		limit := b[0].LeftMax(1)
+		limit := b.Rows[0].LeftMax(1)
 		Printf("limit: %d\n", limit)
 		plays := b.GenerateRowMoves(0, r, dict)
-		res := []Play{}
+		res := []Move{}
 		for p := range plays {
 			Printf("Play: %#v\n", p)
 			res = append(res, p)
@@ -333,3 +347,25 @@ func TestGenerateRowMoves(t *testing.T) {
 		So(res, ShouldNotBeEmpty)
 	})
 }
+
+func TestGenerateRowMovesMinimized(t *testing.T) {
+	Convey("minimized dictionary generates the same plays", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(0, 0, "F")
+
+		collect := func(dict *DAWG) map[moveKey]bool {
+			r := Rack{'F': 1, 'O': 2, 'D': 1, 'L': 1}
+			return playSet(b.GenerateRowMoves(0, r, dict))
+		}
+
+		trie := NewDAWG()
+		min := NewDAWG()
+		for _, w := range []string{"OF", "OOF", "FOOL", "FOOD"} {
+			trie.Add(w)
+			min.Add(w)
+		}
+		min.Minimize()
+
+		So(collect(min), ShouldResemble, collect(trie))
+	})
+}
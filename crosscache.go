@@ -0,0 +1,281 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LetterSet is a set of the letters of a CrossCache, with a bit for
+// each letter's position in its list of letters.
+type LetterSet uint64
+
+// Has returns true if the i'th letter is in s.
+func (s LetterSet) Has(i int) bool {
+	return s&(1<<i) != 0
+}
+
+// ErrTooManyLetters is returned by NewCrossCache for a judge with more
+// letters than fit in a LetterSet.
+var ErrTooManyLetters = errors.New("too many letters for a LetterSet")
+
+// CrossCache holds the cross-checks, cross-scores and anchors of every
+// square of a board, and keeps them up to date as plays are placed
+// with Place, only working them out again for the squares a play
+// affects. Setting a board's Cross to its CrossCache makes the move
+// generators use it instead of working out cross-checks themselves.
+// Plays placed on the board some other way leave the cache out of
+// date.
+type CrossCache struct {
+	// Verify, if set, makes Place check the cache against one built
+	// from scratch, as Check does.
+	Verify bool
+
+	b       *Board
+	j       Judge
+	ts      *TileSet
+	letters []rune
+	index   map[rune]int
+	// checks[dir][y][x] are the letters that may go at x, y in a play
+	// in direction dir, and scores[dir][y][x] the points of the tiles
+	// of the word they'd form the other way.
+	checks  [2][][]LetterSet
+	scores  [2][][]int
+	anchors [][]bool
+	tiles   int
+}
+
+// NewCrossCache works out the cross-checks of every square of b, using
+// j to judge the words formed, and their cross-scores, using the
+// points in ts.
+func NewCrossCache(b *Board, j Judge, ts *TileSet) (*CrossCache, error) {
+	c := &CrossCache{b: b, j: j, ts: ts, letters: letters(j), index: map[rune]int{}}
+	if len(c.letters) > 64 {
+		return nil, ErrTooManyLetters
+	}
+	for i, l := range c.letters {
+		c.index[l] = i
+	}
+	for dir := range c.checks {
+		c.checks[dir] = make([][]LetterSet, len(b.Rows))
+		c.scores[dir] = make([][]int, len(b.Rows))
+	}
+	c.anchors = make([][]bool, len(b.Rows))
+	for y, row := range b.Rows {
+		for dir := range c.checks {
+			c.checks[dir][y] = make([]LetterSet, len(row))
+			c.scores[dir][y] = make([]int, len(row))
+		}
+		c.anchors[y] = make([]bool, len(row))
+		for x, t := range row {
+			if t != Empty {
+				c.tiles++
+			}
+			c.update(x, y, Across)
+			c.update(x, y, Down)
+			c.anchors[y][x] = b.isAnchor(x, y)
+		}
+	}
+	return c, nil
+}
+
+// Letters returns the letters of s.
+func (c *CrossCache) Letters(s LetterSet) []rune {
+	ret := []rune{}
+	for i, l := range c.letters {
+		if s.Has(i) {
+			ret = append(ret, l)
+		}
+	}
+	return ret
+}
+
+// CrossChecks returns the letters that may go at x, y in a play in
+// direction dir without forming a word the other way that isn't one.
+func (c *CrossCache) CrossChecks(x, y int, dir Direction) LetterSet {
+	return c.checks[dir][y][x]
+}
+
+// Allows returns true if letter l may go at x, y in a play in
+// direction dir.
+func (c *CrossCache) Allows(x, y int, dir Direction, l rune) bool {
+	i, ok := c.index[Letter(l)]
+	return ok && c.checks[dir][y][x].Has(i)
+}
+
+// CrossScore returns the points of the tiles already on the board in
+// the word formed the other way by a tile played at x, y in direction
+// dir.
+func (c *CrossCache) CrossScore(x, y int, dir Direction) int {
+	return c.scores[dir][y][x]
+}
+
+// Place puts the new tiles of m on the board and brings the cache up to
+// date. It doesn't check that m is legal; see ValidatePlay.
+func (c *CrossCache) Place(m Move) error {
+	dx, dy := 1, 0
+	if m.Dir == Down {
+		dx, dy = 0, 1
+	}
+	placed := [][2]int{}
+	for i, t := range []rune(m.Word) {
+		x, y := m.X+i*dx, m.Y+i*dy
+		if t == PlayedThrough || c.b.Rows[y][x] != Empty {
+			continue
+		}
+		c.b.Rows[y][x] = t
+		c.tiles++
+		placed = append(placed, [2]int{x, y})
+	}
+
+	for _, p := range placed {
+		x, y := p[0], p[1]
+		c.anchors[y][x] = false
+		c.update(x, y, Across)
+		c.update(x, y, Down)
+		// Only the squares at the ends of the lines of tiles through
+		// a new one form different words.
+		for _, step := range [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+			ex, ey := c.end(x, y, step[0], step[1])
+			if ey < 0 || ey >= len(c.b.Rows) || ex < 0 || ex >= len(c.b.Rows[ey]) {
+				continue
+			}
+			c.anchors[ey][ex] = true
+			if step[0] == 0 {
+				c.update(ex, ey, Across)
+			} else {
+				c.update(ex, ey, Down)
+			}
+		}
+	}
+
+	if c.Verify {
+		return c.Check()
+	}
+	return nil
+}
+
+// Check returns an error if the cache differs from one built from
+// scratch for the board as it is now.
+func (c *CrossCache) Check() error {
+	want, err := NewCrossCache(c.b, c.j, c.ts)
+	if err != nil {
+		return err
+	}
+	if c.tiles != want.tiles {
+		return fmt.Errorf("cross cache has %d tiles on the board, want %d", c.tiles, want.tiles)
+	}
+	for y, row := range c.b.Rows {
+		for x := range row {
+			for _, dir := range []Direction{Across, Down} {
+				if got, want := c.checks[dir][y][x], want.checks[dir][y][x]; got != want {
+					return fmt.Errorf("cross-checks %v at %d,%d are %q, want %q", dir, x, y, string(c.Letters(got)), string(c.Letters(want)))
+				}
+				if got, want := c.scores[dir][y][x], want.scores[dir][y][x]; got != want {
+					return fmt.Errorf("cross-score %v at %d,%d is %d, want %d", dir, x, y, got, want)
+				}
+			}
+			if got, want := c.anchors[y][x], want.anchors[y][x]; got != want {
+				return fmt.Errorf("anchor at %d,%d is %v, want %v", x, y, got, want)
+			}
+		}
+	}
+	return nil
+}
+
+// end returns the first square from x, y in steps of dx, dy that
+// doesn't have a tile on it. It may be off the board.
+func (c *CrossCache) end(x, y, dx, dy int) (int, int) {
+	for {
+		x, y = x+dx, y+dy
+		if y < 0 || y >= len(c.b.Rows) || x < 0 || x >= len(c.b.Rows[y]) || c.b.Rows[y][x] == Empty {
+			return x, y
+		}
+	}
+}
+
+// update works out the cross-checks and cross-score at x, y for plays
+// in direction dir.
+func (c *CrossCache) update(x, y int, dir Direction) {
+	if c.b.Rows[y][x] != Empty {
+		c.checks[dir][y][x] = 0
+		c.scores[dir][y][x] = 0
+		return
+	}
+
+	// The word formed runs the other way.
+	dx, dy := 0, 1
+	if dir == Down {
+		dx, dy = 1, 0
+	}
+	sx, sy := c.end(x, y, -dx, -dy)
+	ex, ey := c.end(x, y, dx, dy)
+	if sx+dx == x && sy+dy == y && ex-dx == x && ey-dy == y {
+		// Nothing either side, so any letter goes.
+		c.checks[dir][y][x] = 1<<len(c.letters) - 1
+		c.scores[dir][y][x] = 0
+		return
+	}
+
+	w := []rune{}
+	at, points := 0, 0
+	for px, py := sx+dx, sy+dy; px != ex || py != ey; px, py = px+dx, py+dy {
+		if px == x && py == y {
+			at = len(w)
+			w = append(w, Empty)
+			continue
+		}
+		t := c.b.Rows[py][px]
+		w = append(w, Letter(t))
+		points += c.ts.Value(t)
+	}
+
+	var s LetterSet
+	for i, l := range c.letters {
+		w[at] = l
+		if c.j.Contains(string(w)) {
+			s |= 1 << i
+		}
+	}
+	c.checks[dir][y][x] = s
+	c.scores[dir][y][x] = points
+}
+
+// letterMap returns s as the map Board.CrossChecks returns, for
+// Tracers.
+func (c *CrossCache) letterMap(s LetterSet) map[rune]bool {
+	ret := map[rune]bool{}
+	for _, l := range c.Letters(s) {
+		ret[l] = true
+	}
+	return ret
+}
+
+// anchorsIn returns the anchors across row y, or down column y if
+// transposed is set.
+func (c *CrossCache) anchorsIn(y int, transposed bool) []int {
+	ret := []int{}
+	if c.tiles == 0 {
+		sx, sy := c.b.Layout.Start()
+		if transposed {
+			sx, sy = sy, sx
+		}
+		if sy == y {
+			ret = append(ret, sx)
+		}
+		return ret
+	}
+	if !transposed {
+		for x, a := range c.anchors[y] {
+			if a {
+				ret = append(ret, x)
+			}
+		}
+		return ret
+	}
+	for x := range c.anchors {
+		if c.anchors[x][y] {
+			ret = append(ret, x)
+		}
+	}
+	return ret
+}
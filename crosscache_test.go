@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCrossCache(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range append(gameWords, testWords...) {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	Convey("kept up to date through a game", t, func() {
+		b := NewBoard(StandardLayout)
+		c, err := NewCrossCache(b, d, EnglishTiles)
+		So(err, ShouldBeNil)
+		c.Verify = true
+		for _, m := range guyVsMacMoves() {
+			So(c.Place(m), ShouldBeNil)
+		}
+		// Unlike PlaceDown, Place leaves the blank G of OUTGREW where
+		// SLOGGING plays through it.
+		So(b.Rows[8][4], ShouldEqual, Blank('G'))
+		So(letterString(b.Rows[8]), ShouldEqual, letterString(guyVsMacBoard().Rows[8]))
+
+		t := b.Transpose()
+		for y, row := range b.Rows {
+			for x, sq := range row {
+				if sq != Empty {
+					continue
+				}
+				So(c.letterMap(c.CrossChecks(x, y, Across)), ShouldResemble, b.CrossChecks(x, y, d))
+				So(c.letterMap(c.CrossChecks(x, y, Down)), ShouldResemble, t.CrossChecks(y, x, d))
+				So(c.CrossScore(x, y, Across), ShouldEqual, b.SidePoints(EnglishTiles, x, y, Empty))
+				So(c.CrossScore(x, y, Down), ShouldEqual, t.SidePoints(EnglishTiles, y, x, Empty))
+			}
+		}
+
+		// O goes after the blank N of SLOGGING to make NO, blank or
+		// not.
+		So(c.Allows(5, 10, Down, 'O'), ShouldBeTrue)
+		So(c.Allows(5, 10, Down, Blank('O')), ShouldBeTrue)
+		So(c.Allows(5, 10, Down, 'X'), ShouldBeFalse)
+		So(c.Allows(5, 10, Across, 'X'), ShouldBeTrue)
+	})
+
+	Convey("generation uses the cache", t, func() {
+		b := guyVsMacBoard()
+		ra := Rack{'A': 1, 'E': 1, 'N': 1, 'O': 1, 'R': 1, 'T': 1, 'D': 1}
+		want := moveMap(GenerateMoves(b, ra, d, EnglishTiles))
+		for y := range b.Rows {
+			across, down := b.Anchors(y), b.Transpose().Anchors(y)
+			b.Cross, _ = NewCrossCache(b, d, EnglishTiles)
+			So(b.Anchors(y), ShouldResemble, across)
+			So(b.Transpose().Anchors(y), ShouldResemble, down)
+			b.Cross = nil
+		}
+
+		var err error
+		b.Cross, err = NewCrossCache(b, d, EnglishTiles)
+		So(err, ShouldBeNil)
+		So(moveMap(GenerateMoves(b, ra, d, EnglishTiles)), ShouldResemble, want)
+	})
+
+	Convey("an empty board", t, func() {
+		b := NewBoard(StandardLayout)
+		b.Cross, _ = NewCrossCache(b, d, EnglishTiles)
+		So(b.Anchors(7), ShouldResemble, []int{7})
+		So(b.Transpose().Anchors(7), ShouldResemble, []int{7})
+		So(b.Anchors(6), ShouldBeEmpty)
+		So(b.Cross.Allows(7, 7, Across, 'Q'), ShouldBeTrue)
+	})
+
+	Convey("checking finds a stale cache", t, func() {
+		b := NewBoard(StandardLayout)
+		c, _ := NewCrossCache(b, d, EnglishTiles)
+		So(c.Place(Move{X: 7, Y: 7, Dir: Across, Word: "CAR"}), ShouldBeNil)
+		So(c.Check(), ShouldBeNil)
+		b.PlaceAcross(7, 8, "OF")
+		So(c.Check(), ShouldNotBeNil)
+	})
+}
+
+// moveMap returns moves by where they go and what they spell.
+func moveMap(moves []Move) map[moveKey]Move {
+	ret := map[moveKey]Move{}
+	for _, m := range moves {
+		ret[m.key()] = m
+	}
+	return ret
+}
@@ -1,16 +1,28 @@
 package main
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
 // Directed Acyclic Word Graph
 // https://en.wikipedia.org/wiki/Deterministic_acyclic_finite_state_automaton
 type DAWG struct {
 	Terminal bool
 	Edge     map[rune]*DAWG
+
+	// count caches the number of words accepted from this node, or
+	// is 0 if they haven't been counted since the node last changed.
+	count int
 }
 
 // Add adds s to the graph, creating new nodes and marking
 // the terminal as necessary.
 func (d *DAWG) Add(s string) {
 	for _, r := range s {
+		d.count = 0
 		next, ok := d.Edge[r]
 		if !ok {
 			next = NewDAWG()
@@ -21,22 +33,24 @@ func (d *DAWG) Add(s string) {
 		d = next
 	}
 	d.Terminal = true
+	d.count = 0
 }
 
 // AddRecursive works like Add but uses a recursive implementation.
 func (d *DAWG) AddRecursive(s string) {
+	d.count = 0
 	if len(s) == 0 {
 		d.Terminal = true
 		return
 	}
-	r := []rune(s)[0]
+	r, size := utf8.DecodeRuneInString(s)
 	next, ok := d.Edge[r]
 	if !ok {
 		next = NewDAWG()
 		d.Edge[r] = next
 	}
 
-	next.AddRecursive(s[1:])
+	next.AddRecursive(s[size:])
 }
 
 // Contains returns true if s reaches a terminal state starting at d.
@@ -71,3 +85,162 @@ func (v Visitor) Traverse(d *DAWG, f func(e rune, d *DAWG)) {
 		v.Traverse(g, f)
 	}
 }
+
+// NodeCount returns the number of distinct nodes reachable from d,
+// including d itself. Unlike totalNodes it reflects any sharing
+// introduced by Minimize.
+func (d *DAWG) NodeCount() int {
+	n := 1
+	Visitor{}.Traverse(d, func(e rune, g *DAWG) {
+		n++
+	})
+	return n
+}
+
+// Minimize merges equivalent subgraphs below d so that nodes
+// accepting the same set of suffixes are shared, turning the prefix
+// trie built by Add into a minimal DAWG. Contains and Traverse work
+// the same afterwards, but since nodes may now be reachable along
+// several paths, Add must not be called on a minimized graph. Use
+// Remove or ApplyDelta to change one instead.
+func (d *DAWG) Minimize() {
+	r := newRegister()
+	r.replaceChildren(d, map[*DAWG]bool{})
+}
+
+// register holds one canonical node per equivalence class, keyed by
+// the node's terminal flag and its (already canonical) outgoing edges.
+type register struct {
+	nodes map[string]*DAWG
+	ids   map[*DAWG]int
+}
+
+func newRegister() *register {
+	return &register{
+		nodes: map[string]*DAWG{},
+		ids:   map[*DAWG]int{},
+	}
+}
+
+// signature returns a key that is equal for two nodes iff they are
+// terminal alike and have identical edges to canonical children.
+func (r *register) signature(d *DAWG) string {
+	var sb strings.Builder
+	if d.Terminal {
+		sb.WriteByte('1')
+	} else {
+		sb.WriteByte('0')
+	}
+	for _, e := range sortedEdges(d) {
+		sb.WriteRune(e)
+		sb.WriteString(strconv.Itoa(r.ids[d.Edge[e]]))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// canonical returns the registered node equivalent to d, registering
+// d itself if there is none yet. All of d's children must already
+// be canonical.
+func (r *register) canonical(d *DAWG) *DAWG {
+	sig := r.signature(d)
+	if c, ok := r.nodes[sig]; ok {
+		return c
+	}
+	r.nodes[sig] = d
+	r.ids[d] = len(r.ids) + 1
+	return d
+}
+
+// replaceChildren canonicalizes the graph below d depth first, so that
+// children are always registered before their parents.
+func (r *register) replaceChildren(d *DAWG, done map[*DAWG]bool) {
+	for e, child := range d.Edge {
+		if !done[child] {
+			r.replaceChildren(child, done)
+			done[child] = true
+		}
+		d.Edge[e] = r.canonical(child)
+	}
+}
+
+// Builder constructs a minimal DAWG incrementally from words added in
+// sorted order, following Daciuk, Mihov, Watson & Watson (2000),
+// "Incremental Construction of Minimal Acyclic Finite-State Automata".
+// Only the path of the most recently inserted word is ever left
+// unminimized, so the full trie never has to fit in memory.
+type Builder struct {
+	root     *DAWG
+	previous string
+	reg      *register
+
+	// unchecked holds the nodes along the previous word's path that
+	// haven't been checked for equivalence yet, shallowest first.
+	unchecked []uncheckedEdge
+}
+
+type uncheckedEdge struct {
+	parent *DAWG
+	r      rune
+	child  *DAWG
+}
+
+// NewBuilder returns a Builder with an empty graph.
+func NewBuilder() *Builder {
+	return &Builder{
+		root: NewDAWG(),
+		reg:  newRegister(),
+	}
+}
+
+// Insert adds s to the graph. Words must be inserted in strictly
+// increasing order; an error is returned for any word that sorts
+// before or equal to the previously inserted one.
+func (b *Builder) Insert(s string) error {
+	if b.previous != "" && s <= b.previous {
+		return fmt.Errorf("word %q inserted out of order after %q", s, b.previous)
+	}
+
+	// Find the length of the common prefix with the previous word,
+	// measured in edges (runes), not bytes.
+	common := 0
+	prev := []rune(b.previous)
+	word := []rune(s)
+	for common < len(prev) && common < len(word) && prev[common] == word[common] {
+		common++
+	}
+
+	b.minimize(common)
+
+	node := b.root
+	if len(b.unchecked) > 0 {
+		node = b.unchecked[len(b.unchecked)-1].child
+	}
+	for _, r := range word[common:] {
+		next := NewDAWG()
+		node.Edge[r] = next
+		b.unchecked = append(b.unchecked, uncheckedEdge{node, r, next})
+		node = next
+	}
+	node.Terminal = true
+	b.previous = s
+	return nil
+}
+
+// minimize replaces unchecked nodes deeper than depth with their
+// registered equivalents.
+func (b *Builder) minimize(depth int) {
+	for i := len(b.unchecked) - 1; i >= depth; i-- {
+		u := b.unchecked[i]
+		u.parent.Edge[u.r] = b.reg.canonical(u.child)
+	}
+	b.unchecked = b.unchecked[:depth]
+}
+
+// Finish minimizes whatever remains of the last inserted word and
+// returns the root of the completed graph. The Builder must not be
+// used afterwards.
+func (b *Builder) Finish() *DAWG {
+	b.minimize(0)
+	return b.root
+}
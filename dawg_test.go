@@ -0,0 +1,95 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testWords = []string{
+	"CAR", "CARE", "CARED", "CARES", "CARS", "CART", "CARTS",
+	"DARE", "DARED", "DARES", "FOOD", "FOOL", "OF", "OOF",
+	"TAR", "TARE", "TARED", "TARES", "TARS",
+}
+
+func TestMinimize(t *testing.T) {
+	Convey("minimized trie", t, func() {
+		trie := NewDAWG()
+		for _, w := range testWords {
+			trie.Add(w)
+		}
+		before := trie.NodeCount()
+
+		d := NewDAWG()
+		for _, w := range testWords {
+			d.Add(w)
+		}
+		d.Minimize()
+		So(d.NodeCount(), ShouldBeLessThan, before)
+
+		Convey("accepts the same words", func() {
+			for _, w := range testWords {
+				So(d.Contains(w), ShouldBeTrue)
+			}
+			for _, w := range []string{"", "C", "CA", "DAR", "TART", "CARSE", "FO", "OO"} {
+				So(d.Contains(w), ShouldEqual, trie.Contains(w))
+			}
+		})
+
+		Convey("shares suffixes", func() {
+			// CAR-, DAR- and TAR- all accept E, ED, ES after their
+			// prefix, so the nodes after CARE, DARE and TARE are one.
+			care := d.Edge['C'].Edge['A'].Edge['R'].Edge['E']
+			dare := d.Edge['D'].Edge['A'].Edge['R'].Edge['E']
+			tare := d.Edge['T'].Edge['A'].Edge['R'].Edge['E']
+			So(care, ShouldEqual, dare)
+			So(dare, ShouldEqual, tare)
+			So(d.Edge['C'], ShouldNotEqual, d.Edge['T'])
+		})
+
+		Convey("traverses each node once", func() {
+			seen := map[*DAWG]int{}
+			Visitor{}.Traverse(d, func(e rune, g *DAWG) {
+				seen[g]++
+			})
+			So(len(seen), ShouldEqual, d.NodeCount()-1)
+			for _, n := range seen {
+				So(n, ShouldEqual, 1)
+			}
+		})
+	})
+}
+
+func TestBuilder(t *testing.T) {
+	Convey("sorted input", t, func() {
+		words := slices.Clone(testWords)
+		slices.Sort(words)
+
+		b := NewBuilder()
+		for _, w := range words {
+			So(b.Insert(w), ShouldBeNil)
+		}
+		d := b.Finish()
+
+		m := NewDAWG()
+		for _, w := range words {
+			m.Add(w)
+		}
+		m.Minimize()
+
+		So(d.NodeCount(), ShouldEqual, m.NodeCount())
+		for _, w := range words {
+			So(d.Contains(w), ShouldBeTrue)
+		}
+		So(d.Contains("CA"), ShouldBeFalse)
+		So(d.Contains("CARTE"), ShouldBeFalse)
+	})
+
+	Convey("unsorted input", t, func() {
+		b := NewBuilder()
+		So(b.Insert("DOG"), ShouldBeNil)
+		So(b.Insert("CAT"), ShouldNotBeNil)
+		So(b.Insert("DOG"), ShouldNotBeNil)
+	})
+}
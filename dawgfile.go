@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"slices"
+)
+
+// On-disk DAWG format. All integers are little-endian uint32s.
+//
+//	magic     "DAWG"
+//	version   dawgFileVersion
+//	alphabet  count, followed by count runes
+//	nodes     node count
+//	edges     edge count
+//	checksum  CRC-32 (IEEE) of the rest of the file, from the magic
+//	          on, leaving out the checksum itself
+//
+// The header is followed by one record per node and then one record
+// per edge. Node 0 is the root. A node record is its first edge index
+// shifted left by one, with the low bit set if the node is terminal;
+// a node's edges run up to the next node's first edge (or the end of
+// the edge array for the last node). An edge record is the index of
+// its letter in the alphabet in the low 8 bits and the index of the
+// node it leads to in the remaining 24.
+const (
+	dawgFileMagic   = "DAWG"
+	dawgFileVersion = 2
+
+	edgeLetterBits = 8
+	edgeLetterMask = 1<<edgeLetterBits - 1
+	maxFileNodes   = 1 << (32 - edgeLetterBits)
+)
+
+var ErrBadDAWGFile = errors.New("not a valid DAWG file")
+
+// WriteTo writes the graph rooted at d to w in the binary format
+// read by ReadDAWG.
+func (d *DAWG) WriteTo(w io.Writer) (int64, error) {
+	// Number the nodes breadth first so the root is 0 and shared
+	// nodes are written once.
+	ids := map[*DAWG]uint32{d: 0}
+	order := []*DAWG{d}
+	alphabet := []rune{}
+	seen := map[rune]bool{}
+	for i := 0; i < len(order); i++ {
+		n := order[i]
+		for _, r := range sortedEdges(n) {
+			if !seen[r] {
+				seen[r] = true
+				alphabet = append(alphabet, r)
+			}
+			child := n.Edge[r]
+			if _, ok := ids[child]; !ok {
+				ids[child] = uint32(len(order))
+				order = append(order, child)
+			}
+		}
+	}
+	if len(order) > maxFileNodes {
+		return 0, fmt.Errorf("graph has %d nodes, format allows %d", len(order), maxFileNodes)
+	}
+	if len(alphabet) > edgeLetterMask+1 {
+		return 0, fmt.Errorf("graph has %d letters, format allows %d", len(alphabet), edgeLetterMask+1)
+	}
+	slices.Sort(alphabet)
+	letters := map[rune]uint32{}
+	for i, r := range alphabet {
+		letters[r] = uint32(i)
+	}
+
+	nodes := make([]uint32, len(order))
+	edges := []uint32{}
+	for i, n := range order {
+		nodes[i] = uint32(len(edges)) << 1
+		if n.Terminal {
+			nodes[i] |= 1
+		}
+		for _, r := range sortedEdges(n) {
+			edges = append(edges, ids[n.Edge[r]]<<edgeLetterBits|letters[r])
+		}
+	}
+
+	var body bytes.Buffer
+	binary.Write(&body, binary.LittleEndian, nodes)
+	binary.Write(&body, binary.LittleEndian, edges)
+
+	var head bytes.Buffer
+	head.WriteString(dawgFileMagic)
+	header := []uint32{dawgFileVersion, uint32(len(alphabet))}
+	for _, r := range alphabet {
+		header = append(header, uint32(r))
+	}
+	header = append(header, uint32(len(nodes)), uint32(len(edges)))
+	binary.Write(&head, binary.LittleEndian, header)
+	sum := crc32.NewIEEE()
+	sum.Write(head.Bytes())
+	sum.Write(body.Bytes())
+	binary.Write(&head, binary.LittleEndian, sum.Sum32())
+
+	n, err := head.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	m, err := body.WriteTo(w)
+	return n + m, err
+}
+
+// ReadDAWG reads a graph written by DAWG.WriteTo.
+func ReadDAWG(r io.Reader) (*DAWG, error) {
+	br := bufio.NewReader(r)
+	// Everything but the checksum is read through sr, to be summed.
+	sum := crc32.NewIEEE()
+	sr := io.TeeReader(br, sum)
+
+	magic := make([]byte, len(dawgFileMagic))
+	if _, err := io.ReadFull(sr, magic); err != nil {
+		return nil, fmt.Errorf("reading magic: %w", err)
+	}
+	if string(magic) != dawgFileMagic {
+		return nil, ErrBadDAWGFile
+	}
+
+	var version, alphabetLen uint32
+	if err := binary.Read(sr, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("reading version: %w", err)
+	}
+	if version != dawgFileVersion {
+		return nil, fmt.Errorf("unsupported DAWG file version %d", version)
+	}
+	if err := binary.Read(sr, binary.LittleEndian, &alphabetLen); err != nil {
+		return nil, fmt.Errorf("reading alphabet: %w", err)
+	}
+	if alphabetLen > edgeLetterMask+1 {
+		return nil, fmt.Errorf("%w: alphabet of %d letters", ErrBadDAWGFile, alphabetLen)
+	}
+	alphabet := make([]uint32, alphabetLen)
+	if err := binary.Read(sr, binary.LittleEndian, alphabet); err != nil {
+		return nil, fmt.Errorf("reading alphabet: %w", err)
+	}
+
+	var counts struct {
+		Nodes, Edges uint32
+	}
+	if err := binary.Read(sr, binary.LittleEndian, &counts); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	var checksum uint32
+	if err := binary.Read(br, binary.LittleEndian, &checksum); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if counts.Nodes == 0 || counts.Nodes > maxFileNodes {
+		return nil, fmt.Errorf("%w: %d nodes", ErrBadDAWGFile, counts.Nodes)
+	}
+	// Each node has at most an edge per letter.
+	if uint64(counts.Edges) > uint64(counts.Nodes)*uint64(alphabetLen) {
+		return nil, fmt.Errorf("%w: %d edges for %d nodes", ErrBadDAWGFile, counts.Edges, counts.Nodes)
+	}
+
+	// Read what's there rather than allocating all the header asks for
+	// up front, in case it's wrong.
+	size := 4 * (int64(counts.Nodes) + int64(counts.Edges))
+	body, err := io.ReadAll(io.LimitReader(sr, size))
+	if err != nil {
+		return nil, fmt.Errorf("reading graph: %w", err)
+	}
+	if int64(len(body)) != size {
+		return nil, fmt.Errorf("reading graph: %w", io.ErrUnexpectedEOF)
+	}
+	if sum.Sum32() != checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrBadDAWGFile)
+	}
+
+	word := func(i uint32) uint32 {
+		return binary.LittleEndian.Uint32(body[4*i:])
+	}
+
+	nodes := make([]*DAWG, counts.Nodes)
+	for i := range nodes {
+		nodes[i] = NewDAWG()
+	}
+	for i, n := range nodes {
+		rec := word(uint32(i))
+		n.Terminal = rec&1 == 1
+		first, end := rec>>1, counts.Edges
+		if uint32(i+1) < counts.Nodes {
+			end = word(uint32(i+1)) >> 1
+		}
+		if first > end || end > counts.Edges {
+			return nil, fmt.Errorf("%w: node %d has edges %d..%d", ErrBadDAWGFile, i, first, end)
+		}
+		for e := first; e < end; e++ {
+			edge := word(counts.Nodes + e)
+			letter, child := edge&edgeLetterMask, edge>>edgeLetterBits
+			if letter >= alphabetLen || child >= counts.Nodes {
+				return nil, fmt.Errorf("%w: edge %d out of range", ErrBadDAWGFile, e)
+			}
+			n.Edge[rune(alphabet[letter])] = nodes[child]
+		}
+	}
+	// Every walk of the graph must come to an end, or there'd be no
+	// end to its words.
+	if !acyclic(counts.Nodes, counts.Edges, word) {
+		return nil, fmt.Errorf("%w: graph has a cycle", ErrBadDAWGFile)
+	}
+
+	return nodes[0], nil
+}
+
+// acyclic reports whether no path from the root of the graph in the
+// node and edge records read by word leads back to a node on it. The
+// records must already have been checked to be in range.
+func acyclic(nodes, edges uint32, word func(uint32) uint32) bool {
+	const (
+		unseen = iota
+		onPath
+		done
+	)
+	state := make([]uint8, nodes)
+	type frame struct{ node, edge, end uint32 }
+	visit := func(i uint32) frame {
+		state[i] = onPath
+		end := edges
+		if i+1 < nodes {
+			end = word(i+1) >> 1
+		}
+		return frame{i, word(i) >> 1, end}
+	}
+
+	// Walk depth first without recursing, as a bad file can make the
+	// path as long as the graph is big.
+	path := []frame{visit(0)}
+	for len(path) > 0 {
+		f := &path[len(path)-1]
+		if f.edge == f.end {
+			state[f.node] = done
+			path = path[:len(path)-1]
+			continue
+		}
+		child := word(nodes+f.edge) >> edgeLetterBits
+		f.edge++
+		switch state[child] {
+		case onPath:
+			return false
+		case unseen:
+			path = append(path, visit(child))
+		}
+	}
+	return true
+}
+
+// sortedEdges returns the runes labelling d's outgoing edges in
+// ascending order.
+func sortedEdges(d *DAWG) []rune {
+	ret := make([]rune, 0, len(d.Edge))
+	for r := range d.Edge {
+		ret = append(ret, r)
+	}
+	slices.Sort(ret)
+	return ret
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDAWGFile(t *testing.T) {
+	Convey("round trip", t, func() {
+		d := NewDAWG()
+		for _, w := range testWords {
+			d.Add(w)
+		}
+		d.Minimize()
+
+		var buf bytes.Buffer
+		n, err := d.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, buf.Len())
+
+		got, err := ReadDAWG(&buf)
+		So(err, ShouldBeNil)
+		So(got.NodeCount(), ShouldEqual, d.NodeCount())
+		for _, w := range testWords {
+			So(got.Contains(w), ShouldBeTrue)
+		}
+		for _, w := range []string{"", "C", "CARTE", "DARTS", "OOFS", "ZZZ"} {
+			So(got.Contains(w), ShouldEqual, d.Contains(w))
+		}
+	})
+
+	Convey("empty graph", t, func() {
+		var buf bytes.Buffer
+		_, err := NewDAWG().WriteTo(&buf)
+		So(err, ShouldBeNil)
+
+		got, err := ReadDAWG(&buf)
+		So(err, ShouldBeNil)
+		So(got.Terminal, ShouldBeFalse)
+		So(got.Edge, ShouldBeEmpty)
+	})
+
+	Convey("bad input", t, func() {
+		d := NewDAWG()
+		d.Add("DOG")
+		var buf bytes.Buffer
+		_, err := d.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		good := buf.Bytes()
+
+		Convey("wrong magic", func() {
+			bad := bytes.Clone(good)
+			bad[0] = 'X'
+			_, err := ReadDAWG(bytes.NewReader(bad))
+			So(errors.Is(err, ErrBadDAWGFile), ShouldBeTrue)
+		})
+
+		Convey("corrupted body", func() {
+			bad := bytes.Clone(good)
+			bad[len(bad)-1] ^= 0xff
+			_, err := ReadDAWG(bytes.NewReader(bad))
+			So(errors.Is(err, ErrBadDAWGFile), ShouldBeTrue)
+		})
+
+		Convey("corrupted alphabet", func() {
+			bad := bytes.Clone(good)
+			// The first letter, after the magic, version and
+			// alphabet length.
+			bad[12] ^= 0x01
+			_, err := ReadDAWG(bytes.NewReader(bad))
+			So(errors.Is(err, ErrBadDAWGFile), ShouldBeTrue)
+		})
+
+		Convey("too many edges", func() {
+			bad := bytes.Clone(good)
+			// The edge count follows the 3 letter alphabet and the
+			// node count.
+			binary.LittleEndian.PutUint32(bad[12+3*4+4:], 0xffffffff)
+			_, err := ReadDAWG(bytes.NewReader(bad))
+			So(errors.Is(err, ErrBadDAWGFile), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "edges for")
+		})
+
+		Convey("a cycle", func() {
+			bad := bytes.Clone(good)
+			// The G from the node after DO leads back to the node
+			// after D. The edges follow the 36 byte header and the 4
+			// nodes.
+			binary.LittleEndian.PutUint32(bad[36+4*4+2*4:], 1<<edgeLetterBits|1)
+			binary.LittleEndian.PutUint32(bad[32:], crc32.ChecksumIEEE(append(bytes.Clone(bad[:32]), bad[36:]...)))
+			_, err := ReadDAWG(bytes.NewReader(bad))
+			So(errors.Is(err, ErrBadDAWGFile), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "cycle")
+		})
+
+		Convey("truncated", func() {
+			_, err := ReadDAWG(bytes.NewReader(good[:len(good)-2]))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
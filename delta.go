@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Remove removes s from the graph, pruning any nodes left without
+// words, and returns false if s wasn't there to begin with.
+//
+// The nodes along s are copied rather than modified, since in a
+// minimized graph they may be shared with other words, so Remove is
+// safe to call on a graph built by Minimize or a Builder. Call
+// Minimize again afterwards to restore minimal form.
+func (d *DAWG) Remove(s string) bool {
+	if !d.Contains(s) {
+		return false
+	}
+
+	word := []rune(s)
+	path := d.unshare(word)
+	path[len(path)-1].Terminal = false
+
+	// Prune from the end of the word back towards the root.
+	for i := len(word); i > 0; i-- {
+		if n := path[i]; n.Terminal || len(n.Edge) > 0 {
+			break
+		}
+		delete(path[i-1].Edge, word[i-1])
+	}
+	return true
+}
+
+// insert adds s to the graph like Add, but copies rather than modifies
+// existing nodes along the way, like Remove.
+func (d *DAWG) insert(s string) {
+	path := d.unshare([]rune(s))
+	path[len(path)-1].Terminal = true
+}
+
+// unshare replaces each node along word with a copy of its own,
+// creating any that are missing, and returns them starting with d.
+// Cached counts along the path are reset, since the caller is about
+// to change them.
+func (d *DAWG) unshare(word []rune) []*DAWG {
+	d.count = 0
+	path := []*DAWG{d}
+	for _, r := range word {
+		next := NewDAWG()
+		if old, ok := d.Edge[r]; ok {
+			next.Terminal = old.Terminal
+			for e, g := range old.Edge {
+				next.Edge[e] = g
+			}
+		}
+		d.Edge[r] = next
+		path = append(path, next)
+		d = next
+	}
+	return path
+}
+
+// DeltaOp is a single line of a delta file: a word to add or remove.
+type DeltaOp struct {
+	Remove bool
+	Word   string
+}
+
+// ReadDelta reads a lexicon delta, one +WORD or -WORD per line. Blank
+// lines and lines starting with # are ignored.
+func ReadDelta(r io.Reader) ([]DeltaOp, error) {
+	ret := []DeltaOp{}
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		op := DeltaOp{Word: strings.TrimSpace(line[1:])}
+		switch line[0] {
+		case '+':
+		case '-':
+			op.Remove = true
+		default:
+			return nil, fmt.Errorf("line %d: %q doesn't start with + or -", n, line)
+		}
+		if op.Word == "" {
+			return nil, fmt.Errorf("line %d: missing word", n)
+		}
+		ret = append(ret, op)
+	}
+	return ret, s.Err()
+}
+
+// DeltaSummary records what applying a delta changed.
+type DeltaSummary struct {
+	Added   []string
+	Removed []string
+	// Words that were added but already present, or removed but
+	// already absent.
+	AlreadyPresent []string
+	NotFound       []string
+}
+
+func (s DeltaSummary) String() string {
+	return fmt.Sprintf("%d added, %d removed, %d already present, %d not found",
+		len(s.Added), len(s.Removed), len(s.AlreadyPresent), len(s.NotFound))
+}
+
+// ApplyDelta returns a new, minimized graph with the operations in ops
+// applied in order, along with a summary of what changed. d itself
+// still accepts the same words afterwards.
+func (d *DAWG) ApplyDelta(ops []DeltaOp) (*DAWG, DeltaSummary) {
+	var summary DeltaSummary
+
+	ret := NewDAWG()
+	ret.Terminal = d.Terminal
+	for r, next := range d.Edge {
+		ret.Edge[r] = next
+	}
+
+	for _, op := range ops {
+		switch {
+		case op.Remove && ret.Remove(op.Word):
+			summary.Removed = append(summary.Removed, op.Word)
+		case op.Remove:
+			summary.NotFound = append(summary.NotFound, op.Word)
+		case ret.Contains(op.Word):
+			summary.AlreadyPresent = append(summary.AlreadyPresent, op.Word)
+		default:
+			ret.insert(op.Word)
+			summary.Added = append(summary.Added, op.Word)
+		}
+	}
+
+	ret.Minimize()
+	return ret, summary
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRemove(t *testing.T) {
+	Convey("trie", t, func() {
+		d := NewDAWG()
+		for _, w := range testWords {
+			d.Add(w)
+		}
+		nodes := d.NodeCount()
+
+		So(d.Remove("CARTS"), ShouldBeTrue)
+		So(d.Contains("CARTS"), ShouldBeFalse)
+		So(d.Contains("CART"), ShouldBeTrue)
+		// The S node after CART is pruned.
+		So(d.NodeCount(), ShouldEqual, nodes-1)
+
+		So(d.Remove("CARTS"), ShouldBeFalse)
+		So(d.Remove("CA"), ShouldBeFalse)
+		So(d.Remove("ZZZ"), ShouldBeFalse)
+
+		So(d.Remove("CART"), ShouldBeTrue)
+		So(d.Contains("CAR"), ShouldBeTrue)
+		So(d.Count(), ShouldEqual, len(testWords)-2)
+	})
+
+	Convey("minimized", t, func() {
+		d := NewDAWG()
+		for _, w := range testWords {
+			d.Add(w)
+		}
+		d.Minimize()
+		before := d.Count()
+
+		// CARED, DARED and TARED share their final nodes.
+		So(d.Remove("CARED"), ShouldBeTrue)
+		So(d.Contains("CARED"), ShouldBeFalse)
+		So(d.Contains("DARED"), ShouldBeTrue)
+		So(d.Contains("TARED"), ShouldBeTrue)
+		So(d.Contains("CARES"), ShouldBeTrue)
+		So(d.Count(), ShouldEqual, before-1)
+
+		d.Minimize()
+		So(d.Contains("CARED"), ShouldBeFalse)
+		So(d.Contains("DARED"), ShouldBeTrue)
+	})
+
+	Convey("everything", t, func() {
+		d := NewDAWG()
+		for _, w := range testWords {
+			d.Add(w)
+		}
+		for _, w := range testWords {
+			So(d.Remove(w), ShouldBeTrue)
+		}
+		So(d.Edge, ShouldBeEmpty)
+		So(d.NodeCount(), ShouldEqual, 1)
+	})
+}
+
+func TestDelta(t *testing.T) {
+	Convey("read", t, func() {
+		ops, err := ReadDelta(strings.NewReader("# changes\n+QI\r\n\n-CARTS\n  +ZA  \n"))
+		So(err, ShouldBeNil)
+		So(ops, ShouldResemble, []DeltaOp{
+			{Word: "QI"},
+			{Remove: true, Word: "CARTS"},
+			{Word: "ZA"},
+		})
+
+		_, err = ReadDelta(strings.NewReader("+QI\nZA\n"))
+		So(err, ShouldNotBeNil)
+		_, err = ReadDelta(strings.NewReader("+QI\n-\n"))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("apply", t, func() {
+		d := NewDAWG()
+		for _, w := range testWords {
+			d.Add(w)
+		}
+		d.Minimize()
+
+		got, summary := d.ApplyDelta([]DeltaOp{
+			{Word: "QI"},
+			{Word: "CARTED"},
+			{Remove: true, Word: "CARTS"},
+			{Remove: true, Word: "TARED"},
+			{Word: "CAR"},
+			{Remove: true, Word: "XU"},
+		})
+		So(summary, ShouldResemble, DeltaSummary{
+			Added:          []string{"QI", "CARTED"},
+			Removed:        []string{"CARTS", "TARED"},
+			AlreadyPresent: []string{"CAR"},
+			NotFound:       []string{"XU"},
+		})
+
+		want := []string{"CARTED", "QI"}
+		for _, w := range testWords {
+			if w != "CARTS" && w != "TARED" {
+				want = append(want, w)
+			}
+		}
+		slices.Sort(want)
+		So(slices.Collect(got.Words()), ShouldResemble, want)
+
+		Convey("leaves the original alone", func() {
+			So(slices.Collect(d.Words()), ShouldResemble, testWords)
+		})
+
+		Convey("minimal", func() {
+			m := NewDAWG()
+			for _, w := range want {
+				m.Add(w)
+			}
+			m.Minimize()
+			So(got.NodeCount(), ShouldEqual, m.NodeCount())
+		})
+	})
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DOTOptions restricts the part of a graph written by WriteDOT.
+type DOTOptions struct {
+	// Prefix, if set, starts the graph at the node reached by Prefix
+	// rather than at the root.
+	Prefix string
+	// MaxDepth, if positive, stops MaxDepth edges from the start.
+	// Nodes with edges left out are drawn dashed.
+	MaxDepth int
+}
+
+// WriteDOT writes the graph rooted at d to w in Graphviz DOT format,
+// with terminal nodes double-circled and each edge labelled with its
+// rune. Shared nodes are drawn once, so minimization shows up as edges
+// converging on the same node.
+func (d *DAWG) WriteDOT(w io.Writer, opts DOTOptions) error {
+	start := d
+	for _, r := range opts.Prefix {
+		if start = start.Edge[r]; start == nil {
+			return fmt.Errorf("no words start with %q", opts.Prefix)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph DAWG {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, "\tnode [shape=circle, label=\"\"];")
+
+	ids := map[*DAWG]int{start: 0}
+	level := []*DAWG{start}
+	for depth := 0; len(level) > 0; depth++ {
+		next := []*DAWG{}
+		for _, n := range level {
+			attrs := []string{}
+			switch {
+			case n == start && opts.Prefix != "":
+				attrs = append(attrs, "label="+dotQuote(opts.Prefix), "shape=box")
+				if n.Terminal {
+					attrs = append(attrs, "peripheries=2")
+				}
+			case n.Terminal:
+				attrs = append(attrs, "shape=doublecircle")
+			}
+			cut := opts.MaxDepth > 0 && depth >= opts.MaxDepth
+			if cut && len(n.Edge) > 0 {
+				attrs = append(attrs, "style=dashed")
+			}
+			if len(attrs) > 0 {
+				fmt.Fprintf(bw, "\tn%d [%s];\n", ids[n], strings.Join(attrs, ", "))
+			}
+			if cut {
+				continue
+			}
+
+			for _, r := range sortedEdges(n) {
+				child := n.Edge[r]
+				if _, ok := ids[child]; !ok {
+					ids[child] = len(ids)
+					next = append(next, child)
+				}
+				fmt.Fprintf(bw, "\tn%d -> n%d [label=%s];\n", ids[n], ids[child], dotQuote(string(r)))
+			}
+		}
+		level = next
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote returns s as a double-quoted DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteDOT(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range []string{"CAR", "CARE", "DARE", `A"B`} {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	Convey("whole graph", t, func() {
+		var buf bytes.Buffer
+		So(d.WriteDOT(&buf, DOTOptions{}), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `digraph DAWG {
+	rankdir=LR;
+	node [shape=circle, label=""];
+	n0 -> n1 [label="A"];
+	n0 -> n2 [label="C"];
+	n0 -> n3 [label="D"];
+	n1 -> n4 [label="\""];
+	n2 -> n5 [label="A"];
+	n3 -> n6 [label="A"];
+	n4 -> n7 [label="B"];
+	n5 -> n8 [label="R"];
+	n6 -> n9 [label="R"];
+	n7 [shape=doublecircle];
+	n8 [shape=doublecircle];
+	n8 -> n7 [label="E"];
+	n9 -> n7 [label="E"];
+}
+`)
+	})
+
+	Convey("prefix and depth", t, func() {
+		var buf bytes.Buffer
+		So(d.WriteDOT(&buf, DOTOptions{Prefix: "CA", MaxDepth: 1}), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `digraph DAWG {
+	rankdir=LR;
+	node [shape=circle, label=""];
+	n0 [label="CA", shape=box];
+	n0 -> n1 [label="R"];
+	n1 [shape=doublecircle, style=dashed];
+}
+`)
+		So(strings.Count(buf.String(), "->"), ShouldEqual, 1)
+	})
+
+	Convey("missing prefix", t, func() {
+		var buf bytes.Buffer
+		So(d.WriteDOT(&buf, DOTOptions{Prefix: "Q"}), ShouldNotBeNil)
+	})
+}
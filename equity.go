@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxLeave is the most tiles a leave in a LeaveValues table has.
+const MaxLeave = 6
+
+// LeaveValues is a table of what the tiles left on the rack after a
+// play are worth, keyed by leaveKey.
+type LeaveValues map[string]float64
+
+// ReadLeaves reads a table of leave values from r, with a line for
+// each leave giving its tiles, spelled with the tiles of ts and ? for
+// blanks, and its value, separated by a comma:
+//
+//	?S,25.6
+//	QU,-2.1
+//
+// A header line of LEAVE,VALUE, blank lines and lines starting with #
+// are skipped.
+func ReadLeaves(r io.Reader, ts *TileSet) (LeaveValues, error) {
+	lv := LeaveValues{}
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.EqualFold(line, "LEAVE,VALUE") {
+			continue
+		}
+		leave, value, ok := strings.Cut(line, ",")
+		if !ok {
+			return nil, fmt.Errorf("line %d: want leave and value, got %q", n, line)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: bad value %q", n, value)
+		}
+		ra, err := ts.Rack(strings.TrimSpace(leave))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if c := ra.Count(); c == 0 || c > MaxLeave {
+			return nil, fmt.Errorf("line %d: leave %q has %d tiles, want 1 to %d", n, leave, c, MaxLeave)
+		}
+		lv[leaveKey(ra)] = v
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return lv, nil
+}
+
+// Value returns what leaving the tiles of ts on ra is worth: from lv
+// if it has ra, or else as worked out by HeuristicLeave. Leaving
+// nothing is worth nothing.
+func (lv LeaveValues) Value(ra Rack, ts *TileSet) float64 {
+	if ra.Count() == 0 {
+		return 0
+	}
+	if v, ok := lv[leaveKey(ra)]; ok {
+		return v
+	}
+	return HeuristicLeave(ra, ts)
+}
+
+// leaveKey returns the tiles on ra in order, as a string.
+func leaveKey(ra Rack) string {
+	tiles := []rune{}
+	for t, n := range ra {
+		for range n {
+			tiles = append(tiles, t)
+		}
+	}
+	slices.Sort(tiles)
+	return string(tiles)
+}
+
+// What HeuristicLeave counts for and against a leave.
+const (
+	blankValue       = 20.0
+	imbalancePenalty = 3.0
+	duplicatePenalty = 4.0
+	qWithoutUPenalty = 8.0
+)
+
+// HeuristicLeave returns a rough value for leaving the tiles of ts on
+// ra, for when there's no table of leave values. Blanks are worth
+// keeping; leaves with many more of ts.Vowels than consonants or the
+// other way round, more than one of a tile, or a Q without a U, aren't.
+// The last only counts where Q and U are tiles of their own.
+func HeuristicLeave(ra Rack, ts *TileSet) float64 {
+	v := 0.0
+	vowels, consonants := 0, 0
+	for t, n := range ra {
+		if n <= 0 {
+			continue
+		}
+		if t == Empty {
+			v += blankValue * float64(n)
+			continue
+		}
+		if ts.Vowels[t] {
+			vowels += n
+		} else {
+			consonants += n
+		}
+		v -= duplicatePenalty * float64(n-1)
+	}
+	if d := vowels - consonants; d > 1 || d < -1 {
+		v -= imbalancePenalty * float64(max(d, -d)-1)
+	}
+	q, qok := ts.Alphabet.codes["Q"]
+	u, uok := ts.Alphabet.codes["U"]
+	if qok && uok && ra[q] > 0 && ra[u] == 0 {
+		v -= qWithoutUPenalty
+	}
+	return v
+}
+
+// Equity returns what m, played with the tiles of ts, is worth: its
+// score plus the value of its leave in lv. lv may be nil, in which case
+// leaves are valued by HeuristicLeave.
+func Equity(m Move, lv LeaveValues, ts *TileSet) float64 {
+	return float64(m.Score) + lv.Value(m.Leave, ts)
+}
+
+// BestMoves returns the n plays on b using tiles from ra with the most
+// equity, best first, with their Equity filled in. If n isn't
+// positive every play is returned.
+func BestMoves(b *Board, ra Rack, lex Lexicon, ts *TileSet, lv LeaveValues, n int) []Move {
+	moves := GenerateMoves(b, ra, lex, ts)
+	for i := range moves {
+		moves[i].Equity = Equity(moves[i], lv, ts)
+	}
+	// Ties go to the higher score, and then to where the play is, so
+	// that the order doesn't depend on the order they're found in.
+	slices.SortFunc(moves, func(p, q Move) int {
+		return cmp.Or(
+			cmp.Compare(q.Equity, p.Equity),
+			cmp.Compare(q.Score, p.Score),
+			cmp.Compare(p.Y, q.Y),
+			cmp.Compare(p.X, q.X),
+			cmp.Compare(p.Dir, q.Dir),
+			strings.Compare(p.Word, q.Word),
+		)
+	})
+	if n > 0 && n < len(moves) {
+		moves = moves[:n]
+	}
+	return moves
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLeaveValues(t *testing.T) {
+	Convey("reading", t, func() {
+		lv, err := ReadLeaves(strings.NewReader("LEAVE,VALUE\n# best\n?S,25.5\nQU,-2\n\nEIS, 10.25\n"), EnglishTiles)
+		So(err, ShouldBeNil)
+		So(len(lv), ShouldEqual, 3)
+		So(lv.Value(Rack{'S': 1, Empty: 1}, EnglishTiles), ShouldEqual, 25.5)
+		So(lv.Value(Rack{'U': 1, 'Q': 1}, EnglishTiles), ShouldEqual, -2)
+		So(lv.Value(Rack{'S': 1, 'I': 1, 'E': 1, 'X': 0}, EnglishTiles), ShouldEqual, 10.25)
+		So(lv.Value(Rack{}, EnglishTiles), ShouldEqual, 0)
+		// Leaves not in the table are guessed at.
+		So(lv.Value(Rack{'Q': 1}, EnglishTiles), ShouldEqual, HeuristicLeave(Rack{'Q': 1}, EnglishTiles))
+
+		lv, err = ReadLeaves(strings.NewReader("[CH]?,12\n"), SpanishTiles)
+		So(err, ShouldBeNil)
+		ch := []rune(Spanish.MustEncode("CH"))[0]
+		So(lv.Value(Rack{ch: 1, Empty: 1}, SpanishTiles), ShouldEqual, 12)
+		// A CH and a vowel are balanced.
+		So(lv.Value(Rack{ch: 1, 'A': 1}, SpanishTiles), ShouldEqual, 0)
+	})
+
+	Convey("bad tables", t, func() {
+		for _, in := range []string{
+			"AB\n",
+			"AB,x\n",
+			"A1,2\n",
+			"ABCDEFG,2\n",
+			"ZZ,2\n",
+			",2\n",
+		} {
+			_, err := ReadLeaves(strings.NewReader(in), EnglishTiles)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("heuristic", t, func() {
+		h := func(ra Rack) float64 { return HeuristicLeave(ra, EnglishTiles) }
+		So(h(Rack{}), ShouldEqual, 0)
+		So(h(Rack{Empty: 1}), ShouldBeGreaterThan, h(Rack{'S': 1}))
+		So(h(Rack{'E': 1, 'R': 1, 'S': 1}), ShouldBeGreaterThan, h(Rack{'E': 1, 'I': 1, 'O': 1}))
+		So(h(Rack{'E': 1, 'R': 1, 'S': 1}), ShouldBeGreaterThan, h(Rack{'B': 1, 'R': 1, 'S': 1}))
+		So(h(Rack{'E': 1, 'R': 1, 'S': 1}), ShouldBeGreaterThan, h(Rack{'E': 1, 'S': 2}))
+		So(h(Rack{'Q': 1, 'U': 1}), ShouldBeGreaterThan, h(Rack{'Q': 1, 'A': 1}))
+	})
+
+	Convey("vowels come from the tile set", t, func() {
+		So(GermanTiles.Vowels['Ä'], ShouldBeTrue)
+		So(PolishTiles.Vowels['Ą'], ShouldBeTrue)
+		So(PolishTiles.Vowels['Y'], ShouldBeTrue)
+		So(EnglishTiles.Vowels['Y'], ShouldBeFalse)
+		ch := []rune(Spanish.MustEncode("CH"))[0]
+		So(SpanishTiles.Vowels[ch], ShouldBeFalse)
+
+		So(HeuristicLeave(Rack{'Ä': 1, 'Ö': 1, 'Ü': 1}, GermanTiles), ShouldEqual, HeuristicLeave(Rack{'A': 1, 'O': 1, 'U': 1}, GermanTiles))
+		So(HeuristicLeave(Rack{ch: 1, 'A': 1}, SpanishTiles), ShouldEqual, 0)
+
+		// With no U of its own, a QU tile isn't a Q without a U.
+		ts := MustTileSet("QU 1 10\nA 1 1\nE 1 1\n")
+		So(ts.Vowels, ShouldHaveLength, 2)
+		qu := []rune(ts.Alphabet.MustEncode("QU"))[0]
+		So(HeuristicLeave(Rack{qu: 1, 'A': 1}, ts), ShouldEqual, 0)
+	})
+}
+
+func TestBestMoves(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range []string{"QI", "QAT", "AT", "TA", "TAE", "ETA", "EAT", "TEA"} {
+		d.Add(w)
+	}
+	d.Minimize()
+	ra := Rack{'Q': 1, 'A': 1, 'T': 1, 'E': 1, 'I': 1}
+
+	Convey("ranked by equity", t, func() {
+		b := NewBoard(StandardLayout)
+		all := BestMoves(b, ra, d, EnglishTiles, nil, 0)
+		So(len(all), ShouldEqual, len(GenerateMoves(b, ra, d, EnglishTiles)))
+		for i, m := range all {
+			So(m.Equity, ShouldEqual, Equity(m, nil, EnglishTiles))
+			if i > 0 {
+				So(m.Equity, ShouldBeLessThanOrEqualTo, all[i-1].Equity)
+			}
+		}
+
+		top := BestMoves(b, ra, d, EnglishTiles, nil, 3)
+		So(top, ShouldResemble, all[:3])
+		// Getting rid of the Q is worth more than the points for
+		// keeping it.
+		So(strings.ContainsRune(top[0].Tiles, 'Q'), ShouldBeTrue)
+	})
+
+	Convey("a leave table changes the order", t, func() {
+		b := NewBoard(StandardLayout)
+		lv := LeaveValues{leaveKey(Rack{'E': 1, 'I': 1}): 100}
+		top := BestMoves(b, ra, d, EnglishTiles, lv, 1)
+		So(top, ShouldHaveLength, 1)
+		So(top[0].Leave, ShouldResemble, Rack{'E': 1, 'I': 1})
+		So(top[0].Word, ShouldEqual, "QAT")
+		So(top[0].Equity, ShouldEqual, float64(top[0].Score)+100)
+	})
+}
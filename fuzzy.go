@@ -0,0 +1,70 @@
+package main
+
+// Suggestion is a word within some number of edits of a query.
+type Suggestion struct {
+	Word     string
+	Distance int
+}
+
+// Suggest returns every word in d within k edits of query, in lexical
+// order, for "did you mean" suggestions. An edit is an insertion,
+// deletion or substitution of a single letter, or if transpositions
+// is set, a swap of two adjacent letters.
+//
+// Rather than measuring the distance to every word, Suggest walks d
+// computing one row of the Levenshtein table per letter, and gives up
+// on a branch as soon as every entry in its row exceeds k.
+func (d *DAWG) Suggest(query string, k int, transpositions bool) []Suggestion {
+	q := []rune(query)
+	row := make([]int, len(q)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	s := &suggester{q: q, k: k, transpositions: transpositions}
+	if d.Terminal && row[len(q)] <= k {
+		s.ret = append(s.ret, Suggestion{"", row[len(q)]})
+	}
+	for _, r := range sortedEdges(d) {
+		s.walk(d.Edge[r], []rune{r}, nil, row)
+	}
+	return s.ret
+}
+
+type suggester struct {
+	q              []rune
+	k              int
+	transpositions bool
+	ret            []Suggestion
+}
+
+// walk visits node, reached by word, given the table rows for word
+// without its last letter (prev) and without its last two (prevprev).
+func (s *suggester) walk(node *DAWG, word []rune, prevprev, prev []int) {
+	c := word[len(word)-1]
+	row := make([]int, len(prev))
+	row[0] = prev[0] + 1
+	best := row[0]
+	for j := 1; j < len(row); j++ {
+		cost := 1
+		if s.q[j-1] == c {
+			cost = 0
+		}
+		row[j] = min(prev[j]+1, row[j-1]+1, prev[j-1]+cost)
+		if s.transpositions && prevprev != nil && j > 1 &&
+			s.q[j-1] == word[len(word)-2] && s.q[j-2] == c {
+			row[j] = min(row[j], prevprev[j-2]+1)
+		}
+		best = min(best, row[j])
+	}
+	if best > s.k {
+		return
+	}
+
+	if n := row[len(row)-1]; node.Terminal && n <= s.k {
+		s.ret = append(s.ret, Suggestion{string(word), n})
+	}
+	for _, r := range sortedEdges(node) {
+		s.walk(node.Edge[r], append(word, r), prev, row)
+	}
+}
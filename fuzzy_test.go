@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// editDistance is the textbook full-table Levenshtein (optimal string
+// alignment) distance, to check Suggest against.
+func editDistance(a, b string, transpositions bool) int {
+	x, y := []rune(a), []rune(b)
+	t := make([][]int, len(x)+1)
+	for i := range t {
+		t[i] = make([]int, len(y)+1)
+		t[i][0] = i
+	}
+	for j := range t[0] {
+		t[0][j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			t[i][j] = min(t[i-1][j]+1, t[i][j-1]+1, t[i-1][j-1]+cost)
+			if transpositions && i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				t[i][j] = min(t[i][j], t[i-2][j-2]+1)
+			}
+		}
+	}
+	return t[len(x)][len(y)]
+}
+
+func TestSuggest(t *testing.T) {
+	words := append([]string{"THE"}, append(gameWords, testWords...)...)
+	d := NewDAWG()
+	for _, w := range words {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	Convey("k=1", t, func() {
+		So(d.Suggest("CARTE", 1, false), ShouldResemble, []Suggestion{
+			{"CARE", 1}, {"CART", 1}, {"CARTS", 1},
+		})
+		So(d.Suggest("CARE", 0, false), ShouldResemble, []Suggestion{{"CARE", 0}})
+		So(d.Suggest("XYZZY", 1, false), ShouldBeEmpty)
+	})
+
+	Convey("transpositions", t, func() {
+		So(d.Suggest("TEH", 1, false), ShouldResemble, []Suggestion{{"EH", 1}, {"TEA", 1}, {"TEN", 1}})
+		So(d.Suggest("TEH", 1, true), ShouldResemble, []Suggestion{{"EH", 1}, {"TEA", 1}, {"TEN", 1}, {"THE", 1}})
+	})
+
+	Convey("matches brute force", t, func() {
+		for _, q := range []string{"CARTE", "TEH", "DNOE", "QUITOR", "A", "", "HYALIEN"} {
+			for k := 0; k <= 2; k++ {
+				for _, tr := range []bool{false, true} {
+					want := []Suggestion{}
+					for w := range d.Words() {
+						if n := editDistance(q, w, tr); n <= k {
+							want = append(want, Suggestion{w, n})
+						}
+					}
+					got := d.Suggest(q, k, tr)
+					if got == nil {
+						got = []Suggestion{}
+					}
+					So(got, ShouldResemble, want)
+				}
+			}
+		}
+	})
+}
+
+func benchmarkSuggest(b *testing.B, k int) {
+	d := NewDAWG()
+	for _, w := range benchWords(b) {
+		d.Add(w)
+	}
+	d.Minimize()
+	queries := []string{"recieve", "definately", "seperate", "occured", "wierd", "acheive"}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		d.Suggest(queries[n%len(queries)], k, true)
+	}
+}
+
+func BenchmarkSuggestK1(b *testing.B) {
+	benchmarkSuggest(b, 1)
+}
+
+func BenchmarkSuggestK2(b *testing.B) {
+	benchmarkSuggest(b, 2)
+}
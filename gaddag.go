@@ -0,0 +1,205 @@
+package main
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Separator marks the point in a GADDAG path where the reversed
+// prefix ends and the suffix begins. Gordon's paper writes it as ◊.
+const Separator = '^'
+
+// GADDAG is the bidirectional word graph from Steven A. Gordon (1994),
+// "A Faster Scrabble Move Generation Algorithm". Every word w is stored
+// once for each way of splitting it into a non-empty prefix and a
+// suffix, as REV(prefix) ◊ suffix, with the ◊ omitted when the suffix
+// is empty. Starting from any letter of a word, the graph can thus be
+// followed leftwards to the start of the word, then across the
+// Separator and rightwards to its end.
+type GADDAG struct {
+	Root *DAWG
+
+	// Alphabet is the set of tiles words are spelled with, or nil for
+	// A to Z. CrossChecks tries each of them.
+	Alphabet *Alphabet
+}
+
+// Letters returns the runes of g's tiles.
+func (g *GADDAG) Letters() []rune {
+	if g.Alphabet == nil {
+		return englishLetters
+	}
+	return g.Alphabet.Letters()
+}
+
+func NewGADDAG() *GADDAG {
+	return &GADDAG{Root: NewDAWG()}
+}
+
+// BuildGADDAG returns a minimized GADDAG of words. It builds the graph
+// incrementally with a Builder, so unlike calling Add for each word
+// followed by Minimize, the much larger unminimized graph never has
+// to fit in memory.
+func BuildGADDAG(words []string) *GADDAG {
+	paths := []string{}
+	for _, w := range words {
+		paths = append(paths, gaddagPaths(w)...)
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	b := NewBuilder()
+	for _, p := range paths {
+		// Sorted and deduplicated above, so this can't fail.
+		b.Insert(p)
+	}
+	return &GADDAG{Root: b.Finish()}
+}
+
+// Add adds every split of s to the graph.
+func (g *GADDAG) Add(s string) {
+	for _, p := range gaddagPaths(s) {
+		g.Root.Add(p)
+	}
+}
+
+// gaddagPaths returns the strings stored in a GADDAG for s.
+func gaddagPaths(s string) []string {
+	w := []rune(s)
+	if len(w) == 0 {
+		return nil
+	}
+	ret := []string{}
+	for i := 1; i < len(w); i++ {
+		ret = append(ret, string(reversed(w[:i]))+string(Separator)+string(w[i:]))
+	}
+	return append(ret, string(reversed(w)))
+}
+
+// Minimize shares equivalent subgraphs; see DAWG.Minimize.
+func (g *GADDAG) Minimize() {
+	g.Root.Minimize()
+}
+
+// Contains returns true if s was added to the graph.
+func (g *GADDAG) Contains(s string) bool {
+	if s == "" {
+		return false
+	}
+	return g.Root.Contains(string(reversed([]rune(s))))
+}
+
+func reversed(w []rune) []rune {
+	ret := make([]rune, len(w))
+	for i, r := range w {
+		ret[len(w)-1-i] = r
+	}
+	return ret
+}
+
+// GenerateRowMovesGADDAG finds the same plays as GenerateRowMoves, but
+// grows each play outwards from its anchor square in both directions
+// using g, rather than trying every left part that fits.
+func (b Board) GenerateRowMovesGADDAG(y int, ra Rack, g *GADDAG) chan Move {
+	ret := make(chan Move)
+	go func() {
+		for _, x := range b.Anchors(y) {
+			if b.Tracer != nil {
+				b.Tracer.Anchor(x, y)
+			}
+			left, limit := b.anchorLeft(x, y)
+			if left != "" {
+				// Tiles to the left are read off the board
+				// instead, and no more may be added beyond them.
+				limit = utf8.RuneCountInString(left)
+			}
+			gen := &gaddagGen{
+				b:      b,
+				y:      y,
+				anchor: x,
+				first:  x - limit,
+				ra:     ra,
+				g:      g,
+				plays:  ret,
+			}
+			gen.gen(x, nil, g.Root)
+		}
+		close(ret)
+	}()
+	return ret
+}
+
+// gaddagGen holds the state of a search from a single anchor.
+type gaddagGen struct {
+	b      Board
+	y      int
+	anchor int
+	// first is the leftmost square the play may cover.
+	first int
+	ra    Rack
+	g     *GADDAG
+	plays chan Move
+}
+
+// gen is Gordon's Gen: place or read the tile at x and continue
+// along node.
+func (gg *gaddagGen) gen(x int, word []rune, node *DAWG) {
+	if gg.b.Tracer != nil {
+		gg.b.Tracer.Extend(x, gg.y, string(word))
+	}
+	row := gg.b.Rows[gg.y]
+	if l := row[x]; l != Empty {
+		gg.goOn(x, l, word, node.Edge[Letter(l)])
+		return
+	}
+
+	crossChecks := gg.b.crossChecks(x, gg.y, gg.g)
+	if gg.b.Tracer != nil {
+		gg.b.Tracer.CrossChecks(x, gg.y, crossChecks.letters())
+	}
+	for r, next := range node.Edge {
+		if r == Separator || !crossChecks.allows(r) {
+			continue
+		}
+		for _, t := range gg.ra.playable(r) {
+			gg.ra.Remove(t)
+			gg.goOn(x, t, word, next)
+			gg.ra.Add(t)
+		}
+	}
+}
+
+// goOn is Gordon's GoOn: having just put l at x and followed its edge
+// to next, record a play if one is complete and keep going, first
+// leftwards and then, across the Separator, rightwards.
+func (gg *gaddagGen) goOn(x int, l rune, word []rune, next *DAWG) {
+	if next == nil {
+		return
+	}
+	row := gg.b.Rows[gg.y]
+	emptyAt := func(x int) bool {
+		return x < 0 || x >= len(row) || row[x] == Empty
+	}
+
+	if x <= gg.anchor {
+		word = append([]rune{l}, word...)
+		if next.Terminal && emptyAt(x-1) && emptyAt(gg.anchor+1) {
+			gg.b.emit(gg.plays, gg.b.rowMove(gg.anchor+1, gg.y, string(word), gg.ra))
+		}
+		if x-1 >= gg.first {
+			gg.gen(x-1, word, next)
+		}
+		if sep := next.Edge[Separator]; sep != nil && emptyAt(x-1) && gg.anchor+1 < len(row) {
+			gg.gen(gg.anchor+1, word, sep)
+		}
+		return
+	}
+
+	word = append(word, l)
+	if next.Terminal && emptyAt(x+1) {
+		gg.b.emit(gg.plays, gg.b.rowMove(x+1, gg.y, string(word), gg.ra))
+	}
+	if x+1 < len(row) {
+		gg.gen(x+1, word, next)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var gameWords = []string{
+	"ALACK", "AJEE", "OUTDREW", "HYALINE", "JUNIOR", "FENCES", "BATH",
+	"RITZ", "ZEDS", "SLOGGING", "YIELD", "VAGUE", "RUNTIER", "SONDE",
+	"ME", "PAVAN", "EON", "QUITTOR",
+	"AA", "AB", "AD", "AE", "AG", "AH", "AI", "AL", "AM", "AN", "AR",
+	"AS", "AT", "AW", "AX", "AY", "BE", "BO", "DE", "DO", "ED", "EH",
+	"EL", "EM", "EN", "ER", "ES", "EX", "FA", "GO", "HA", "HE", "HI",
+	"HO", "ID", "IF", "IN", "IS", "IT", "LA", "LI", "LO", "MA", "MI",
+	"MO", "NA", "NE", "NO", "OD", "OE", "OF", "OH", "OI", "OM", "ON",
+	"OR", "OS", "OW", "OX", "OY", "PA", "PE", "PI", "RE", "SH", "SI",
+	"SO", "TA", "TI", "TO", "UN", "US", "UT", "WE", "WO", "XI", "YA",
+	"YE", "ZA", "ARE", "ATE", "DOE", "EAT", "ERA", "ETA", "NOD", "NOT",
+	"ODE", "ONE", "ORE", "RAN", "RAT", "ROD", "ROE", "TAN", "TEA",
+	"TEN", "TOE", "TON", "RATE", "TEAR", "TONE", "NOTE", "DONE", "NODE",
+	"RODE", "TREAD", "TRADE", "ORATE", "ATONE", "RATION", "TENOR",
+	"TONER", "DRONE", "STONE", "NOTES", "ONSET",
+}
+
+func TestGADDAG(t *testing.T) {
+	Convey("contains the same words", t, func() {
+		d := NewDAWG()
+		g := NewGADDAG()
+		for _, w := range testWords {
+			d.Add(w)
+			g.Add(w)
+		}
+		g.Minimize()
+
+		for _, w := range testWords {
+			So(g.Contains(w), ShouldBeTrue)
+		}
+		for _, w := range []string{"", "C", "RAC", "ERAC", "CA^R", "CARTE", "OOFS"} {
+			So(g.Contains(w), ShouldEqual, d.Contains(w))
+		}
+	})
+
+	Convey("paths through every letter", t, func() {
+		g := NewGADDAG()
+		g.Add("CARE")
+		// From R: back to C, across, then on to E.
+		So(g.Root.Contains("RAC^E"), ShouldBeTrue)
+		So(g.Root.Contains("ERAC"), ShouldBeTrue)
+		So(g.Root.Contains("C^ARE"), ShouldBeTrue)
+		So(g.Root.Contains("ERAC^"), ShouldBeFalse)
+	})
+}
+
+// moveKey is where a Move goes and what it spells, which is enough to
+// tell plays apart.
+type moveKey struct {
+	X, Y int
+	Dir  Direction
+	Word string
+}
+
+func (m Move) key() moveKey {
+	return moveKey{m.X, m.Y, m.Dir, m.Word}
+}
+
+func playSet(plays chan Move) map[moveKey]bool {
+	ret := map[moveKey]bool{}
+	for p := range plays {
+		ret[p.key()] = true
+	}
+	return ret
+}
+
+func TestGenerateRowMovesGADDAG(t *testing.T) {
+	Convey("populated", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(0, 0, "F")
+		dict := NewDAWG()
+		g := NewGADDAG()
+		for _, w := range []string{"OF", "OOF", "FOOL", "FOOD"} {
+			dict.Add(w)
+			g.Add(w)
+		}
+
+		want := playSet(b.GenerateRowMoves(0, Rack{'F': 1, 'O': 2, 'D': 1, 'L': 1}, dict))
+		got := playSet(b.GenerateRowMovesGADDAG(0, Rack{'F': 1, 'O': 2, 'D': 1, 'L': 1}, g))
+		So(got, ShouldNotBeEmpty)
+		So(got, ShouldResemble, want)
+	})
+
+	Convey("guy vs mac", t, func() {
+		dict := NewDAWG()
+		g := NewGADDAG()
+		for _, w := range append(gameWords, testWords...) {
+			dict.Add(w)
+			g.Add(w)
+		}
+		dict.Minimize()
+		g.Minimize()
+
+		b := guyVsMacBoard()
+		racks := []Rack{
+			{'A': 1, 'E': 1, 'N': 1, 'O': 1, 'R': 1, 'T': 1, 'D': 1},
+			{'A': 2, 'E': 2, 'H': 1, 'S': 1, 'X': 1},
+			{'O': 1, 'N': 1, 'E': 1, 'S': 1, 'T': 1, 'I': 1, 'M': 1},
+		}
+
+		total := 0
+		for _, board := range []*Board{b, b.Transpose()} {
+			for y := range board.Rows {
+				for _, ra := range racks {
+					want := playSet(board.GenerateRowMoves(y, ra, dict))
+					got := playSet(board.GenerateRowMovesGADDAG(y, ra, g))
+					So(got, ShouldResemble, want)
+					total += len(got)
+				}
+			}
+		}
+		So(total, ShouldBeGreaterThan, 0)
+	})
+}
+
+// guyVsMacMoves returns the plays of the "guy vs mac" game in
+// TestPlaysAndScoring, in order.
+func guyVsMacMoves() []Move {
+	ret := []Move{}
+	for _, p := range []struct {
+		across bool
+		x, y   int
+		word   string
+	}{
+		{true, 7, 7, "ALACK"},
+		{true, 11, 8, "AJEE"},
+		{true, 1, 8, "OUT" + string(Blank('G')) + "REW"},
+		{false, 14, 2, "HYALINE"},
+		{false, 12, 8, "JUNIOR"},
+		{true, 7, 14, "FENCES"},
+		{true, 11, 2, "BATH"},
+		{true, 11, 11, "RITZ"},
+		{false, 14, 11, "ZEDS"},
+		{false, 4, 4, "SLOGGI" + string(Blank('N')) + "G"},
+		{true, 1, 5, "YIELD"},
+		{true, 7, 3, "VAGUE"},
+		{true, 2, 13, "RUNTIER"},
+		{true, 8, 4, "SONDE"},
+		{true, 8, 2, "ME"},
+		{true, 8, 10, "PAVAN"},
+		{true, 7, 9, "EON"},
+		{false, 2, 7, "QUITTOR"},
+	} {
+		dir := Down
+		if p.across {
+			dir = Across
+		}
+		ret = append(ret, Move{X: p.x, Y: p.y, Dir: dir, Word: p.word})
+	}
+	return ret
+}
+
+// guyVsMacBoard returns the board at the end of the "guy vs mac"
+// game in TestPlaysAndScoring.
+func guyVsMacBoard() *Board {
+	b := NewBoard(StandardLayout)
+	for _, m := range guyVsMacMoves() {
+		if m.Dir == Across {
+			b.PlaceAcross(m.X, m.Y, m.Word)
+		} else {
+			b = b.PlaceDown(m.X, m.Y, m.Word)
+		}
+	}
+	return b
+}
+
+func BenchmarkGenerateRowMoves(b *testing.B) {
+	dict := NewDAWG()
+	g := NewGADDAG()
+	for _, w := range append(gameWords, testWords...) {
+		dict.Add(w)
+		g.Add(w)
+	}
+	dict.Minimize()
+	g.Minimize()
+	board := guyVsMacBoard()
+	ra := Rack{'A': 1, 'E': 1, 'N': 1, 'O': 1, 'R': 1, 'T': 1, 'D': 1}
+
+	b.Run("DAWG", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for y := range board.Rows {
+				for range board.GenerateRowMoves(y, ra, dict) {
+				}
+			}
+		}
+	})
+
+	b.Run("GADDAG", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for y := range board.Rows {
+				for range board.GenerateRowMovesGADDAG(y, ra, g) {
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkGenerateMoves(b *testing.B) {
+	dict := NewDAWG()
+	for _, w := range append(gameWords, testWords...) {
+		dict.Add(w)
+	}
+	dict.Minimize()
+	ra := Rack{'A': 1, 'E': 1, 'N': 1, 'O': 1, 'R': 1, 'T': 1, 'D': 1}
+
+	b.Run("no cache", func(b *testing.B) {
+		board := guyVsMacBoard()
+		for n := 0; n < b.N; n++ {
+			GenerateMoves(board, ra, dict, EnglishTiles)
+		}
+	})
+
+	b.Run("cache", func(b *testing.B) {
+		board := guyVsMacBoard()
+		var err error
+		if board.Cross, err = NewCrossCache(board, dict, EnglishTiles); err != nil {
+			b.Fatal(err)
+		}
+		for n := 0; n < b.N; n++ {
+			GenerateMoves(board, ra, dict, EnglishTiles)
+		}
+	})
+}
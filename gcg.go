@@ -16,7 +16,9 @@ type event struct {
 	across, withdrawal           bool
 }
 
-func parseFile(f string) []*event {
+// parseFile reads the moves from the gcg file f, whose words are
+// spelled with the tiles of a.
+func parseFile(f string, a *Alphabet) []*event {
 	ret := []*event{}
 	in, err := os.ReadFile(f)
 	if err != nil {
@@ -29,7 +31,7 @@ func parseFile(f string) []*event {
 		if !strings.HasPrefix(line, ">") {
 			continue
 		}
-		evt := parseLine(line)
+		evt := parseLine(line, a)
 		ret = append(ret, evt)
 	}
 
@@ -46,7 +48,7 @@ func init() {
 	}
 }
 
-func parseLine(s string) *event {
+func parseLine(s string, a *Alphabet) *event {
 	parts := strings.Split(s, " ")
 	for i, p := range parts {
 		parts[i] = strings.Trim(p, " \t")
@@ -73,9 +75,15 @@ func parseLine(s string) *event {
 		return event
 	}
 
+	// Blanks are written in lower case.
+	word, err := a.EncodeTiles(event.word)
+	if err != nil {
+		panic(fmt.Sprintf("parsing word %q: %v", event.word, err))
+	}
+	event.word = word
+
 	var c rune
 	var i int
-	var err error
 	pos := parts[2]
 	if strings.Contains("ABCDEFGHIJKLMNO", string(pos[0])) {
 		c = rune(pos[0])
@@ -8,7 +8,7 @@ import (
 
 func TestParser(t *testing.T) {
 	Convey("basic", t, func() {
-		events := parseFile("1993_wsc_f4_wapnick_nyman.gcg.txt")
+		events := parseFile("1993_wsc_f4_wapnick_nyman.gcg.txt", English)
 		So(events, ShouldNotBeNil)
 	})
 }
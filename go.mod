@@ -2,7 +2,10 @@ module github.com/banksean/dawg
 
 go 1.24.3
 
-require github.com/smartystreets/goconvey v1.8.1
+require (
+	github.com/smartystreets/goconvey v1.8.1
+	golang.org/x/text v0.26.0
+)
 
 require (
 	github.com/gopherjs/gopherjs v1.17.2 // indirect
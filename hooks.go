@@ -0,0 +1,88 @@
+package main
+
+import "slices"
+
+// Reverse returns a minimized DAWG of every word in d spelled
+// backwards, for finding front hooks.
+func (d *DAWG) Reverse() *DAWG {
+	words := []string{}
+	for w := range d.Words() {
+		words = append(words, string(reversed([]rune(w))))
+	}
+	slices.Sort(words)
+
+	b := NewBuilder()
+	for _, w := range words {
+		// Sorted above, and distinct since the originals were.
+		b.Insert(w)
+	}
+	return b.Finish()
+}
+
+// FrontHooks returns, in order, the letters that can be put in front
+// of s to make another word. d must hold words spelled backwards, as
+// returned by Reverse.
+func (d *DAWG) FrontHooks(s string) []rune {
+	if s == "" {
+		return nil
+	}
+	// A word L+s is stored as REV(s) followed by L.
+	node := d
+	for _, r := range reversed([]rune(s)) {
+		if node = node.Edge[r]; node == nil {
+			return nil
+		}
+	}
+	return node.hooks()
+}
+
+// BackHooks returns, in order, the letters that can be put after s
+// to make another word.
+func (d *DAWG) BackHooks(s string) []rune {
+	node := d
+	for _, r := range s {
+		if node = node.Edge[r]; node == nil {
+			return nil
+		}
+	}
+	return node.hooks()
+}
+
+// FrontHooks returns, in order, the letters that can be put in front
+// of s to make another word.
+func (g *GADDAG) FrontHooks(s string) []rune {
+	// Every word is in g backwards, without a Separator, and the
+	// Separator never leads straight to a terminal so it's never
+	// mistaken for a hook.
+	return g.Root.FrontHooks(s)
+}
+
+// BackHooks returns, in order, the letters that can be put after s
+// to make another word.
+func (g *GADDAG) BackHooks(s string) []rune {
+	if s == "" {
+		return nil
+	}
+	// A word s+L is stored as REV(s) ◊ L.
+	node := g.Root
+	for _, r := range reversed([]rune(s)) {
+		if node = node.Edge[r]; node == nil {
+			return nil
+		}
+	}
+	if node = node.Edge[Separator]; node == nil {
+		return []rune{}
+	}
+	return node.hooks()
+}
+
+// hooks returns the letters on d's edges that lead to terminals.
+func (d *DAWG) hooks() []rune {
+	ret := []rune{}
+	for _, r := range sortedEdges(d) {
+		if d.Edge[r].Terminal {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
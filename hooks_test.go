@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHooks(t *testing.T) {
+	words := append([]string{"SCARE", "CARER", "SCARED", "ACARE"}, append(gameWords, testWords...)...)
+	d := NewDAWG()
+	for _, w := range words {
+		d.Add(w)
+	}
+	d.Minimize()
+	rev := d.Reverse()
+	g := BuildGADDAG(words)
+
+	Convey("CARE", t, func() {
+		So(string(rev.FrontHooks("CARE")), ShouldEqual, "AS")
+		So(string(d.BackHooks("CARE")), ShouldEqual, "DRS")
+		So(string(g.FrontHooks("CARE")), ShouldEqual, "AS")
+		So(string(g.BackHooks("CARE")), ShouldEqual, "DRS")
+	})
+
+	Convey("no hooks", t, func() {
+		So(rev.FrontHooks("QUITTOR"), ShouldBeEmpty)
+		So(d.BackHooks("QUITTOR"), ShouldBeEmpty)
+		So(g.FrontHooks("QUITTOR"), ShouldBeEmpty)
+		So(g.BackHooks("QUITTOR"), ShouldBeEmpty)
+		So(g.BackHooks("XYZZY"), ShouldBeEmpty)
+	})
+
+	Convey("GADDAG and DAWG agree", t, func() {
+		for _, w := range words {
+			So(g.FrontHooks(w), ShouldResemble, rev.FrontHooks(w))
+			So(g.BackHooks(w), ShouldResemble, d.BackHooks(w))
+		}
+	})
+
+	Convey("BuildGADDAG", t, func() {
+		a := NewGADDAG()
+		for _, w := range words {
+			a.Add(w)
+		}
+		a.Minimize()
+		So(g.Root.NodeCount(), ShouldEqual, a.Root.NodeCount())
+		for _, w := range words {
+			So(g.Contains(w), ShouldBeTrue)
+		}
+		So(g.Contains("CAREDS"), ShouldBeFalse)
+	})
+}
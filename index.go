@@ -0,0 +1,64 @@
+package main
+
+// Index returns the position of s among the words in d in lexical
+// order, so that every word maps to a distinct number in 0..Count()-1
+// and per-word data can be kept in a slice rather than a map. It
+// returns false if d doesn't contain s.
+//
+// Index and WordAt use the word counts cached in each node by Count,
+// filling them in as needed, so they must not be called concurrently
+// with each other or with Count.
+func (d *DAWG) Index(s string) (int, bool) {
+	i := 0
+	node := d
+	for _, r := range s {
+		// Every word ending here, and every word through a smaller
+		// letter, comes before s.
+		if node.Terminal {
+			i++
+		}
+		next, ok := node.Edge[r]
+		if !ok {
+			return 0, false
+		}
+		for _, e := range sortedEdges(node) {
+			if e == r {
+				break
+			}
+			i += node.Edge[e].Count()
+		}
+		node = next
+	}
+	if !node.Terminal {
+		return 0, false
+	}
+	return i, true
+}
+
+// WordAt returns the word at position i in lexical order, the inverse
+// of Index. It returns "" if i is out of range.
+func (d *DAWG) WordAt(i int) string {
+	if i < 0 || i >= d.Count() {
+		return ""
+	}
+	word := []rune{}
+	node := d
+	for {
+		if node.Terminal {
+			if i == 0 {
+				return string(word)
+			}
+			i--
+		}
+		for _, e := range sortedEdges(node) {
+			next := node.Edge[e]
+			if n := next.Count(); i >= n {
+				i -= n
+				continue
+			}
+			word = append(word, e)
+			node = next
+			break
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIndex(t *testing.T) {
+	words := slices.Clone(append(gameWords, testWords...))
+	slices.Sort(words)
+	words = slices.Compact(words)
+
+	d := NewDAWG()
+	for _, w := range words {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	Convey("sorted list", t, func() {
+		for i, w := range words {
+			n, ok := d.Index(w)
+			So(ok, ShouldBeTrue)
+			So(n, ShouldEqual, i)
+			So(d.WordAt(i), ShouldEqual, w)
+		}
+	})
+
+	Convey("not in the graph", t, func() {
+		for _, w := range []string{"", "C", "CA", "CARTED", "ZZZ"} {
+			_, ok := d.Index(w)
+			So(ok, ShouldBeFalse)
+		}
+		So(d.WordAt(-1), ShouldEqual, "")
+		So(d.WordAt(len(words)), ShouldEqual, "")
+	})
+
+	Convey("after adding", t, func() {
+		d := NewDAWG()
+		for _, w := range []string{"CAR", "CART"} {
+			d.Add(w)
+		}
+		So(d.Count(), ShouldEqual, 2)
+		n, _ := d.Index("CART")
+		So(n, ShouldEqual, 1)
+
+		d.Add("CARE")
+		So(d.Count(), ShouldEqual, 3)
+		n, _ = d.Index("CART")
+		So(n, ShouldEqual, 2)
+		So(d.WordAt(1), ShouldEqual, "CARE")
+	})
+
+	Convey("read from a file", t, func() {
+		var buf bytes.Buffer
+		_, err := d.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		got, err := ReadDAWG(&buf)
+		So(err, ShouldBeNil)
+		for i, w := range words {
+			n, _ := got.Index(w)
+			So(n, ShouldEqual, i)
+		}
+	})
+}
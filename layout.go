@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Layout is the size of a board and the premium squares on it. Layouts
+// needn't be square or symmetric.
+type Layout struct {
+	// squares is row-major, i.e. [y][x].
+	squares        [][]ScoreType
+	startX, startY int
+
+	// transposed is the same layout flipped along its diagonal, so
+	// scoring down the board can use the same code as across.
+	transposed *Layout
+}
+
+// layoutSquares maps the characters of a layout grid to the premium
+// squares they stand for.
+var layoutSquares = map[rune]ScoreType{
+	'.': None,
+	'd': DL,
+	't': TL,
+	'q': QL,
+	'D': DW,
+	'T': TW,
+	'Q': QW,
+	'*': DW,
+	'+': None,
+}
+
+// ReadLayout reads a layout from r as a grid of squares, one line per
+// row:
+//
+//	.  plain square
+//	d  double letter    D  double word
+//	t  triple letter    T  triple word
+//	q  quadruple letter Q  quadruple word
+//	*  the start square, a double word
+//	+  the start square, with no premium
+//
+// Spaces within a row are ignored, as are blank lines and lines
+// starting with #. Every row must be the same width, and there must be
+// exactly one start square.
+func ReadLayout(r io.Reader) (*Layout, error) {
+	l := &Layout{startX: -1}
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.ReplaceAll(strings.TrimSpace(s.Text()), " ", "")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		row := []ScoreType{}
+		for _, c := range line {
+			st, ok := layoutSquares[c]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown square %q", n, c)
+			}
+			if c == '*' || c == '+' {
+				if l.startX >= 0 {
+					return nil, fmt.Errorf("line %d: more than one start square", n)
+				}
+				l.startX, l.startY = len(row), len(l.squares)
+			}
+			row = append(row, st)
+		}
+		if len(l.squares) > 0 && len(row) != len(l.squares[0]) {
+			return nil, fmt.Errorf("line %d: row is %d squares wide, not %d", n, len(row), len(l.squares[0]))
+		}
+		l.squares = append(l.squares, row)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(l.squares) == 0 {
+		return nil, fmt.Errorf("no squares")
+	}
+	if l.startX < 0 {
+		return nil, fmt.Errorf("no start square")
+	}
+
+	t := &Layout{
+		squares:    make([][]ScoreType, l.Width()),
+		startX:     l.startY,
+		startY:     l.startX,
+		transposed: l,
+	}
+	for x := range t.squares {
+		t.squares[x] = make([]ScoreType, l.Height())
+		for y := range t.squares[x] {
+			t.squares[x][y] = l.squares[y][x]
+		}
+	}
+	l.transposed = t
+	return l, nil
+}
+
+// MustLayout is like ReadLayout but reads from a string and panics on
+// error.
+func MustLayout(s string) *Layout {
+	l, err := ReadLayout(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func (l *Layout) Width() int {
+	return len(l.squares[0])
+}
+
+func (l *Layout) Height() int {
+	return len(l.squares)
+}
+
+// Start returns the square the first play must cover.
+func (l *Layout) Start() (x, y int) {
+	return l.startX, l.startY
+}
+
+func (l *Layout) ScoreAt(x, y int) ScoreType {
+	return l.squares[y][x]
+}
+
+// Transpose returns l flipped along its diagonal.
+func (l *Layout) Transpose() *Layout {
+	return l.transposed
+}
+
+var (
+	StandardLayout = MustLayout(`
+T..d...T...d..T
+.D...t...t...D.
+..D...d.d...D..
+d..D...d...D..d
+....D.....D....
+.t...t...t...t.
+..d...d.d...d..
+T..d...*...d..T
+..d...d.d...d..
+.t...t...t...t.
+....D.....D....
+d..D...d...D..d
+..D...d.d...D..
+.D...t...t...D.
+T..d...T...d..T
+`)
+
+	SuperLayout = MustLayout(`
+Q..d...T..d..T...d..Q
+.D..t...D...D...t..D.
+..D..q...D.D...q..D..
+d..T..d...T...d..T..d
+.t..D...t...t...D..t.
+..q..D...d.d...D..q..
+...d..D...d...D..d...
+T......D.....D......T
+.D..t...t...t...t..D.
+..D..d...d.d...d..D..
+d..T..d...*...d..T..d
+..D..d...d.d...d..D..
+.D..t...t...t...t..D.
+T......D.....D......T
+...d..D...d...D..d...
+..q..D...d.d...D..q..
+.t..D...t...t...D..t.
+d..T..d...T...d..T..d
+..D..q...D.D...q..D..
+.D..t...D...D...t..D.
+Q..d...T..d..T...d..Q
+`)
+
+	WordsWithFriendsLayout = MustLayout(`
+...T..t.t..T...
+..d..D...D..d..
+.d..d.....d..d.
+T..t...D...t..T
+..d...d.d...d..
+.D...t...t...D.
+t...d.....d...t
+...D...+...D...
+t...d.....d...t
+.D...t...t...D.
+..d...d.d...d..
+T..t...D...t..T
+.d..d.....d..d.
+..d..D...D..d..
+...T..t.t..T...
+`)
+)
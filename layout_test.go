@@ -0,0 +1,139 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLayout(t *testing.T) {
+	Convey("built-ins", t, func() {
+		for _, l := range []*Layout{StandardLayout, SuperLayout, WordsWithFriendsLayout} {
+			So(l.Width(), ShouldEqual, l.Height())
+			x, y := l.Start()
+			So(x, ShouldEqual, l.Width()/2)
+			So(y, ShouldEqual, l.Height()/2)
+
+			// They all happen to be symmetric.
+			n := l.Width() - 1
+			for y := range l.Height() {
+				for x := range l.Width() {
+					So(l.ScoreAt(x, y), ShouldEqual, l.ScoreAt(y, x))
+					So(l.ScoreAt(x, y), ShouldEqual, l.ScoreAt(n-x, y))
+					So(l.ScoreAt(x, y), ShouldEqual, l.ScoreAt(x, n-y))
+				}
+			}
+		}
+
+		So(SuperLayout.Width(), ShouldEqual, 21)
+		So(SuperLayout.ScoreAt(0, 0), ShouldEqual, QW)
+		So(SuperLayout.ScoreAt(5, 2), ShouldEqual, QL)
+		So(SuperLayout.ScoreAt(10, 10), ShouldEqual, DW)
+		So(WordsWithFriendsLayout.ScoreAt(0, 0), ShouldEqual, None)
+		So(WordsWithFriendsLayout.ScoreAt(3, 0), ShouldEqual, TW)
+		So(WordsWithFriendsLayout.ScoreAt(7, 7), ShouldEqual, None)
+	})
+
+	Convey("reading", t, func() {
+		l, err := ReadLayout(strings.NewReader("# a small board\nT . d . q\n. + . . D\n\n. . t . Q\n"))
+		So(err, ShouldBeNil)
+		So(l.Width(), ShouldEqual, 5)
+		So(l.Height(), ShouldEqual, 3)
+		So(l.ScoreAt(0, 0), ShouldEqual, TW)
+		So(l.ScoreAt(2, 0), ShouldEqual, DL)
+		So(l.ScoreAt(4, 0), ShouldEqual, QL)
+		So(l.ScoreAt(4, 1), ShouldEqual, DW)
+		So(l.ScoreAt(2, 2), ShouldEqual, TL)
+		So(l.ScoreAt(4, 2), ShouldEqual, QW)
+		x, y := l.Start()
+		So(x, ShouldEqual, 1)
+		So(y, ShouldEqual, 1)
+
+		tr := l.Transpose()
+		So(tr.Width(), ShouldEqual, 3)
+		So(tr.Height(), ShouldEqual, 5)
+		So(tr.ScoreAt(0, 4), ShouldEqual, QL)
+		So(tr.ScoreAt(2, 4), ShouldEqual, QW)
+		x, y = tr.Start()
+		So(x, ShouldEqual, 1)
+		So(y, ShouldEqual, 1)
+		So(tr.Transpose(), ShouldEqual, l)
+
+		for _, bad := range []string{
+			"",
+			"...\n...",
+			"..*\n..",
+			"..*\n..+",
+			"..x\n.*.",
+		} {
+			_, err := ReadLayout(strings.NewReader(bad))
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestBoardLayout(t *testing.T) {
+	// Wider than it's tall, with premiums only on the top row.
+	l := MustLayout(`
+.D.....t.
+.........
+....*....
+.........
+`)
+
+	Convey("size", t, func() {
+		b := NewBoard(l)
+		So(b.Rows, ShouldHaveLength, 4)
+		So(b.Rows[0], ShouldHaveLength, 9)
+		So(b.String(), ShouldEqual, strings.Repeat(strings.Repeat(" ", 9)+"\n", 4))
+		So(func() { b.PlaceAcross(5, 0, "TOOLS") }, ShouldPanic)
+		So(func() { b.PlaceDown(0, 1, "TOOL") }, ShouldPanic)
+	})
+
+	Convey("transposition", t, func() {
+		b := NewBoard(l)
+		b.PlaceAcross(0, 0, "CAT")
+		tr := b.Transpose()
+		So(tr.Rows, ShouldHaveLength, 9)
+		So(tr.Rows[1][0], ShouldEqual, 'A')
+		So(tr.Layout.ScoreAt(0, 1), ShouldEqual, DW)
+		So(tr.Transpose(), ShouldResemble, b)
+
+		b = b.PlaceDown(8, 0, "DOG")
+		So(b.Rows[2][8], ShouldEqual, 'G')
+		So(b.Layout, ShouldEqual, l)
+	})
+
+	Convey("scoring", t, func() {
+		b := NewBoard(l)
+		// C A T with the A doubling the word.
+		So(b.ScoreAcross(EnglishTiles, 0, 0, "CAT"), ShouldEqual, 10)
+		// Down the same column only the top square is a premium.
+		So(b.ScoreDown(EnglishTiles, 1, 0, "CAT"), ShouldEqual, 10)
+		So(b.ScoreDown(EnglishTiles, 1, 1, "CAT"), ShouldEqual, 5)
+		// The triple letter is on the top row only.
+		So(b.ScoreAcross(EnglishTiles, 6, 0, "ZAP"), ShouldEqual, 10+3+3)
+		So(b.ScoreAcross(EnglishTiles, 6, 1, "ZAP"), ShouldEqual, 10+1+3)
+	})
+
+	Convey("move generation stays on the board", t, func() {
+		d := NewDAWG()
+		for _, w := range []string{"AT", "CAT", "CATS", "SCAT", "ATE", "ATES", "TA"} {
+			d.Add(w)
+		}
+		b := NewBoard(l)
+		b.PlaceAcross(6, 3, "AT")
+		plays := playSet(b.GenerateRowMoves(3, Rack{'C': 1, 'S': 1, 'E': 1}, d))
+		// CATS and ATE run up to the right edge.
+		So(plays, ShouldResemble, map[moveKey]bool{
+			{5, 3, Across, "CAT"}:  true,
+			{4, 3, Across, "SCAT"}: true,
+			{5, 3, Across, "CATS"}: true,
+			{6, 3, Across, "ATE"}:  true,
+		})
+
+		b.PlaceAcross(5, 2, "A")
+		So(b.CrossChecks(5, 3, d), ShouldResemble, map[rune]bool{'T': true})
+	})
+}
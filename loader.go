@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CaseFold says how LoadWords changes the case of words.
+type CaseFold int
+
+const (
+	KeepCase CaseFold = iota
+	UpperCase
+	LowerCase
+)
+
+// Apply returns s with its case folded.
+func (c CaseFold) Apply(s string) string {
+	switch c {
+	case UpperCase:
+		return strings.ToUpper(s)
+	case LowerCase:
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// Normalization selects a Unicode normalization form for LoadWords.
+type Normalization int
+
+const (
+	NoNormalization Normalization = iota
+	NFC
+	NFD
+	NFKC
+	NFKD
+)
+
+// Apply returns s in normal form n.
+func (n Normalization) Apply(s string) string {
+	switch n {
+	case NFC:
+		return norm.NFC.String(s)
+	case NFD:
+		return norm.NFD.String(s)
+	case NFKC:
+		return norm.NFKC.String(s)
+	case NFKD:
+		return norm.NFKD.String(s)
+	}
+	return s
+}
+
+// LoadOptions controls how LoadWords cleans up a word list.
+type LoadOptions struct {
+	// Comment, if set, marks lines to skip.
+	Comment string
+	Case    CaseFold
+	// Normalize is applied before Case and the checks below.
+	Normalize Normalization
+	// StripAccents removes combining marks, so e.g. É becomes E.
+	StripAccents bool
+	// Alphabet, if set, is the set of tiles words are spelled with.
+	// Each word is encoded into its tiles after normalization and case
+	// folding, and words that can't be are rejected.
+	Alphabet *Alphabet
+	// MinLength and MaxLength bound a word's length in tiles. Zero
+	// means no bound.
+	MinLength, MaxLength int
+	// MaxLines, if positive, stops reading after that many lines.
+	MaxLines int
+}
+
+// Rejection is a line of a word list that LoadWords didn't accept.
+type Rejection struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (r Rejection) String() string {
+	return fmt.Sprintf("line %d: %q: %s", r.Line, r.Text, r.Reason)
+}
+
+// gzipMagic starts every gzip stream.
+const gzipMagic = "\x1f\x8b"
+
+// LoadWords streams a word list from r, one word per line, calling add
+// with each word it accepts. r may be gzip compressed. Surrounding
+// whitespace (including a CR from CRLF line endings) is trimmed, and
+// blank lines and comments are skipped. Lines that don't make
+// acceptable words are returned as rejections. If add returns an error
+// LoadWords stops and returns it.
+func LoadWords(r io.Reader, opts LoadOptions, add func(word string) error) ([]Rejection, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(gzipMagic)); err == nil && string(magic) == gzipMagic {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		br = bufio.NewReader(zr)
+	}
+
+	rejected := []Rejection{}
+	s := bufio.NewScanner(br)
+	for n := 1; s.Scan(); n++ {
+		if opts.MaxLines > 0 && n > opts.MaxLines {
+			break
+		}
+		line := strings.TrimSpace(s.Text())
+		if line == "" || (opts.Comment != "" && strings.HasPrefix(line, opts.Comment)) {
+			continue
+		}
+
+		word, reason := opts.clean(line)
+		if reason != "" {
+			rejected = append(rejected, Rejection{n, line, reason})
+			continue
+		}
+		if err := add(word); err != nil {
+			return rejected, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return rejected, s.Err()
+}
+
+// Fold applies the normalization, accent stripping and case folding in
+// opts to s, so that words looked up in a graph can be put in the same
+// form as the words loaded into it.
+func (opts LoadOptions) Fold(s string) string {
+	s = opts.Normalize.Apply(s)
+	if opts.StripAccents {
+		s = norm.NFC.String(strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Mn, r) {
+				return -1
+			}
+			return r
+		}, norm.NFD.String(s)))
+	}
+	return opts.Case.Apply(s)
+}
+
+// clean returns line as a word, or the reason it isn't one.
+func (opts LoadOptions) clean(line string) (string, string) {
+	if !utf8.ValidString(line) {
+		return "", "invalid UTF-8"
+	}
+
+	word := opts.Fold(line)
+	if opts.Alphabet != nil {
+		var err error
+		if word, err = opts.Alphabet.Encode(word); err != nil {
+			return "", err.Error()
+		}
+	}
+	n := utf8.RuneCountInString(word)
+	if opts.MinLength > 0 && n < opts.MinLength {
+		return "", fmt.Sprintf("shorter than %d", opts.MinLength)
+	}
+	if opts.MaxLength > 0 && n > opts.MaxLength {
+		return "", fmt.Sprintf("longer than %d", opts.MaxLength)
+	}
+	return word, ""
+}
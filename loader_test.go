@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadWords(t *testing.T) {
+	load := func(in string, opts LoadOptions) ([]string, []Rejection, error) {
+		words := []string{}
+		rejected, err := LoadWords(strings.NewReader(in), opts, func(w string) error {
+			words = append(words, w)
+			return nil
+		})
+		return words, rejected, err
+	}
+
+	Convey("whitespace, blanks and comments", t, func() {
+		words, rejected, err := load("CAT\r\n  DOG \n\n# not a word\n\tEMU\n", LoadOptions{Comment: "#"})
+		So(err, ShouldBeNil)
+		So(rejected, ShouldBeEmpty)
+		So(words, ShouldResemble, []string{"CAT", "DOG", "EMU"})
+
+		words, _, _ = load("#HASHTAG\n", LoadOptions{})
+		So(words, ShouldResemble, []string{"#HASHTAG"})
+	})
+
+	Convey("case folding", t, func() {
+		words, _, _ := load("Cat\ndog\n", LoadOptions{Case: UpperCase})
+		So(words, ShouldResemble, []string{"CAT", "DOG"})
+		words, _, _ = load("Cat\ndog\n", LoadOptions{Case: LowerCase})
+		So(words, ShouldResemble, []string{"cat", "dog"})
+		words, _, _ = load("Cat\ndog\n", LoadOptions{})
+		So(words, ShouldResemble, []string{"Cat", "dog"})
+	})
+
+	Convey("normalization", t, func() {
+		decomposed := "CAFE\u0301"
+		words, _, _ := load(decomposed, LoadOptions{Normalize: NFC})
+		So(words, ShouldResemble, []string{"CAFÉ"})
+		words, _, _ = load("CAFÉ", LoadOptions{Normalize: NFD})
+		So(words, ShouldResemble, []string{decomposed})
+		words, _, _ = load("CAFÉ\n"+decomposed+"\nNAÏVE", LoadOptions{StripAccents: true})
+		So(words, ShouldResemble, []string{"CAFE", "CAFE", "NAIVE"})
+	})
+
+	Convey("filters", t, func() {
+		opts := LoadOptions{Case: UpperCase, Alphabet: English, MinLength: 2, MaxLength: 5}
+		words, rejected, err := load("cat\ndon't\nA\nzebras\nqi\n\xff\xfe\n", opts)
+		So(err, ShouldBeNil)
+		So(words, ShouldResemble, []string{"CAT", "QI"})
+		So(len(rejected), ShouldEqual, 4)
+		So(rejected[0].Line, ShouldEqual, 2)
+		So(rejected[0].Text, ShouldEqual, "don't")
+		So(rejected[1].Reason, ShouldEqual, "shorter than 2")
+		So(rejected[2].Reason, ShouldEqual, "longer than 5")
+		So(rejected[3].Reason, ShouldEqual, "invalid UTF-8")
+	})
+
+	Convey("max lines", t, func() {
+		words, _, _ := load("A\nB\nC\nD\n", LoadOptions{MaxLines: 2})
+		So(words, ShouldResemble, []string{"A", "B"})
+	})
+
+	Convey("gzip", t, func() {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte("CAT\nDOG\n"))
+		zw.Close()
+
+		words := []string{}
+		_, err := LoadWords(&buf, LoadOptions{}, func(w string) error {
+			words = append(words, w)
+			return nil
+		})
+		So(err, ShouldBeNil)
+		So(words, ShouldResemble, []string{"CAT", "DOG"})
+	})
+
+	Convey("add errors", t, func() {
+		b := NewBuilder()
+		_, err := LoadWords(strings.NewReader("DOG\nCAT\n"), LoadOptions{}, b.Insert)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, "line 2:")
+
+		stop := errors.New("stop")
+		_, err = LoadWords(strings.NewReader("DOG\n"), LoadOptions{}, func(string) error { return stop })
+		So(errors.Is(err, stop), ShouldBeTrue)
+	})
+}
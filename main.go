@@ -3,37 +3,49 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 
 	"log"
 	"os"
 	"runtime"
 	"runtime/pprof"
+	"slices"
 	"strings"
+	"time"
 )
 
 var (
-	dictFile   = flag.String("dict", "/usr/share/dict/words", "dictionary file")
-	bail       = flag.Int("bail", 0, "bail out after this many lines")
+	dictFile  = flag.String("dict", "/usr/share/dict/words", "dictionary file")
+	lexicon   = flag.String("lexicon", "", "load a prebuilt binary DAWG `file` instead of -dict")
+	build     = flag.String("build", "", "build a binary DAWG from -dict, write it to `file` and exit")
+	setop     = flag.String("setop", "", "combine the two dictionaries given as arguments with `op` (union, intersection or difference) and print the resulting words")
+	dot       = flag.String("dot", "", "write the graph in Graphviz DOT format to `file`")
+	dotPrefix = flag.String("dotprefix", "", "with -dot, only write the graph below `prefix`")
+	dotDepth  = flag.Int("dotdepth", 0, "with -dot, stop this many edges from the start")
+	delta     = flag.String("delta", "", "apply the +WORD/-WORD lines in `file` to the lexicon")
+	pattern   = flag.String("pattern", "", "print the words matching `pattern`, e.g. Q?I?K, *ZZ* or [AEIOU]?T")
+	hooks     = flag.String("hooks", "", "print the front and back hooks of `word`")
+	list      = flag.Bool("list", false, "print every word in lexical order")
+	bail      = flag.Int("bail", 0, "bail out after this many lines")
+
+	comment      = flag.String("comment", "#", "skip dictionary lines starting with `prefix`")
+	caseFold     = flag.String("case", "lower", "fold dictionary words to `case`: lower, upper or keep; with -alphabet, the case its tiles are in")
+	normalize    = flag.String("normalize", "none", "Unicode normalization `form` for dictionary words: none, nfc, nfd, nfkc or nfkd")
+	stripAccents = flag.Bool("stripaccents", false, "remove accents from dictionary words")
+	alphabet     = flag.String("alphabet", "", "spell dictionary words with the tiles of `alphabet`, one of english, spanish, catalan or welsh, or the single letter tiles in a string; reject words that can't be")
+	minLength    = flag.Int("minlen", 0, "reject dictionary words shorter than this")
+	maxLength    = flag.Int("maxlen", 0, "reject dictionary words longer than this")
+
 	recurse    = flag.Bool("recurse", false, "use recrsive Add method")
+	sorted     = flag.Bool("sorted", false, "build the minimal graph incrementally from the words in order, after sorting them again once folded")
 	memprofile = flag.String("memprofile", "", "write memory profile to `file`")
 	cpuprofile = flag.String("cpuprofile", "", "write cpu profile to `file`")
 
 	totalNodes = 0
 )
 
-func init() {
-	flag.Parse()
-}
-
 func main() {
-	byts, err := os.ReadFile(*dictFile)
-	if err != nil {
-		log.Fatalf("trying to read dict file: %#v", err)
-	}
-
-	lines := strings.Split(string(byts), "\n")
-
-	d := NewDAWG()
+	flag.Parse()
 
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
@@ -46,20 +58,19 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
-	for i, line := range lines {
-		if *recurse {
-			d.AddRecursive(strings.ToLower(line))
-		} else {
-			d.Add(strings.ToLower(line))
-		}
+	if *setop != "" {
+		combine(*setop, flag.Args())
+		return
+	}
 
-		if i%1000 == 0 {
-			fmt.Printf("at line %d\n", i)
-		}
-		if *bail != 0 && i >= *bail {
-			break
-		}
+	start := time.Now()
+	var d *DAWG
+	if *lexicon != "" {
+		d = readLexicon(*lexicon)
+	} else {
+		d = readDict(*dictFile)
 	}
+	log.Printf("loaded in %v\n", time.Since(start))
 
 	if *memprofile != "" {
 		f, err := os.Create(*memprofile)
@@ -73,7 +84,45 @@ func main() {
 		f.Close()
 	}
 
-	log.Printf("%d total nodes\n", totalNodes)
+	if *delta != "" {
+		d = applyDelta(*delta, d)
+	}
+
+	stats := d.Stats()
+	log.Printf("%d nodes allocated, %d after minimizing\n", totalNodes, stats.Nodes)
+	log.Printf("%d words, %d edges\n", stats.Words, stats.Edges)
+
+	if *build != "" {
+		writeLexicon(*build, d)
+		return
+	}
+
+	if *dot != "" {
+		writeDOT(*dot, d)
+		return
+	}
+
+	if *list {
+		for w := range d.Words() {
+			fmt.Println(show(w))
+		}
+		return
+	}
+
+	if *pattern != "" {
+		for w := range d.Match(parsePattern(*pattern)) {
+			fmt.Println(show(w))
+		}
+		return
+	}
+
+	if *hooks != "" {
+		w := fold(*hooks)
+		front := d.Reverse().FrontHooks(w)
+		fmt.Println(strings.ToUpper(fmt.Sprintf("%s %s %s", show(string(front)), show(w), show(string(d.BackHooks(w))))))
+		return
+	}
+
 	//d.Add("do")
 	//d.Add("dog")
 	fmt.Printf("%+v\n", d.Contains("a"))
@@ -83,3 +132,290 @@ func main() {
 	fmt.Printf("%+v\n", d.Contains("doggo"))
 	fmt.Printf("%+v\n", d.Contains("asdasd09u0jasd"))
 }
+
+// readDict builds a minimized DAWG from the word list in file,
+// one word per line.
+func readDict(file string) *DAWG {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("trying to read dict file: %v", err)
+	}
+	defer f.Close()
+
+	var d *DAWG
+	var add func(string) error
+	var words []string
+	switch {
+	case *sorted:
+		// Folding can put a sorted list out of order, e.g. Zebra
+		// before apple once lower cased, or make duplicates, so the
+		// words are sorted again before building.
+		add = func(w string) error {
+			words = append(words, w)
+			return nil
+		}
+	case *recurse:
+		d = NewDAWG()
+		add = func(w string) error {
+			d.AddRecursive(w)
+			return nil
+		}
+	default:
+		d = NewDAWG()
+		add = func(w string) error {
+			d.Add(w)
+			return nil
+		}
+	}
+
+	rejected, err := LoadWords(f, loadOptions(), add)
+	for _, r := range rejected {
+		log.Printf("%s: rejected %v\n", file, r)
+	}
+	if err != nil {
+		log.Fatalf("trying to read dict file %s: %v", file, err)
+	}
+	if len(rejected) > 0 {
+		log.Printf("%s: %d lines rejected\n", file, len(rejected))
+	}
+
+	if *sorted {
+		slices.Sort(words)
+		b := NewBuilder()
+		for _, w := range slices.Compact(words) {
+			if err := b.Insert(w); err != nil {
+				log.Fatalf("trying to build from dict file %s: %v", file, err)
+			}
+		}
+		return b.Finish()
+	}
+	d.Minimize()
+	return d
+}
+
+// loadOptions returns the word list options given by flags.
+func loadOptions() LoadOptions {
+	cases := map[string]CaseFold{"keep": KeepCase, "upper": UpperCase, "lower": LowerCase}
+	forms := map[string]Normalization{"none": NoNormalization, "nfc": NFC, "nfd": NFD, "nfkc": NFKC, "nfkd": NFKD}
+
+	c, ok := cases[*caseFold]
+	if !ok {
+		log.Fatalf("unknown -case %q", *caseFold)
+	}
+	n, ok := forms[*normalize]
+	if !ok {
+		log.Fatalf("unknown -normalize %q", *normalize)
+	}
+	a := tiles()
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		set = set || f.Name == "case"
+	})
+	c, err := foldCase(c, set, a)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return LoadOptions{
+		Comment:      *comment,
+		Case:         c,
+		Normalize:    n,
+		StripAccents: *stripAccents,
+		Alphabet:     a,
+		MinLength:    *minLength,
+		MaxLength:    *maxLength,
+		MaxLines:     *bail,
+	}
+}
+
+// foldCase returns the case to fold dictionary words to when they're
+// spelled with the tiles of a, which may be nil: c, or, if -case
+// wasn't set, the case a's tiles are in. It's an error for c to fold
+// words out of a's case, as no word could then be spelled with them.
+func foldCase(c CaseFold, set bool, a *Alphabet) (CaseFold, error) {
+	if a == nil {
+		return c, nil
+	}
+	upper, lower := true, true
+	for _, t := range a.tiles {
+		upper = upper && t == strings.ToUpper(t)
+		lower = lower && t == strings.ToLower(t)
+	}
+	switch {
+	case upper && lower:
+		// Tiles without case, e.g. digits.
+		return c, nil
+	case !set && upper:
+		return UpperCase, nil
+	case !set && lower:
+		return LowerCase, nil
+	case !set:
+		return KeepCase, nil
+	case c == LowerCase && !lower, c == UpperCase && !upper:
+		return c, fmt.Errorf("-case %s can't spell words with the tiles of -alphabet %s", *caseFold, *alphabet)
+	}
+	return c, nil
+}
+
+// fold puts a word given on the command line into the same form as
+// the words loaded from the dictionary.
+func fold(s string) string {
+	opts := loadOptions()
+	s = opts.Fold(s)
+	if opts.Alphabet == nil {
+		return s
+	}
+	ret, err := opts.Alphabet.Encode(s)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return ret
+}
+
+// parsePattern parses a pattern given on the command line, folded like
+// the words loaded from the dictionary. Its letters are split into the
+// tiles of -alphabet once parsed, so that ?, * and [ aren't taken for
+// tiles.
+func parsePattern(s string) *Pattern {
+	opts := loadOptions()
+	p, err := ParseTilePattern(opts.Fold(s), opts.Alphabet)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return p
+}
+
+// tiles returns the alphabet given by -alphabet, or nil if there
+// isn't one.
+func tiles() *Alphabet {
+	builtin := map[string]*Alphabet{"english": English, "spanish": Spanish, "catalan": Catalan, "welsh": Welsh}
+	if *alphabet == "" {
+		return nil
+	}
+	if a, ok := builtin[*alphabet]; ok {
+		return a
+	}
+	a, err := NewAlphabet(strings.Split(*alphabet, "")...)
+	if err != nil {
+		log.Fatalf("bad -alphabet: %v", err)
+	}
+	return a
+}
+
+// show spells out a word from the dictionary for printing.
+func show(w string) string {
+	if a := tiles(); a != nil {
+		return a.Decode(w)
+	}
+	return w
+}
+
+// readLexicon loads a DAWG written by writeLexicon.
+func readLexicon(file string) *DAWG {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("trying to open lexicon: %v", err)
+	}
+	defer f.Close()
+
+	d, err := ReadDAWG(f)
+	if err != nil {
+		log.Fatalf("trying to read lexicon %s: %v", file, err)
+	}
+	return d
+}
+
+func writeLexicon(file string, d *DAWG) {
+	f, err := os.Create(file)
+	if err != nil {
+		log.Fatalf("could not create lexicon: %v", err)
+	}
+	n, err := d.WriteTo(f)
+	if err != nil {
+		log.Fatalf("could not write lexicon: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("could not write lexicon: %v", err)
+	}
+	log.Printf("wrote %d bytes to %s\n", n, file)
+}
+
+// applyDelta returns d with the changes in the delta file applied.
+func applyDelta(file string, d *DAWG) *DAWG {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("trying to open delta: %v", err)
+	}
+	defer f.Close()
+
+	ops, err := ReadDelta(f)
+	if err != nil {
+		log.Fatalf("trying to read delta %s: %v", file, err)
+	}
+	for i := range ops {
+		ops[i].Word = fold(ops[i].Word)
+	}
+
+	d, summary := d.ApplyDelta(ops)
+	log.Printf("delta %s: %v\n", file, summary)
+	for _, w := range summary.AlreadyPresent {
+		log.Printf("already present: %s\n", w)
+	}
+	for _, w := range summary.NotFound {
+		log.Printf("not found: %s\n", w)
+	}
+	return d
+}
+
+// combine prints the words resulting from applying the set operation
+// op to the dictionaries or lexicons in files.
+func combine(op string, files []string) {
+	ops := map[string]func(a, b *DAWG) *DAWG{
+		"union":        Union,
+		"intersection": Intersection,
+		"difference":   Difference,
+	}
+	f, ok := ops[op]
+	if !ok {
+		log.Fatalf("unknown set operation %q", op)
+	}
+	if len(files) != 2 {
+		log.Fatalf("-setop takes two dictionary files, got %d", len(files))
+	}
+
+	for w := range f(readAny(files[0]), readAny(files[1])).Words() {
+		fmt.Println(show(w))
+	}
+}
+
+// readAny loads file as a binary lexicon if it is one, or as a word
+// list otherwise.
+func readAny(file string) *DAWG {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("trying to open %s: %v", file, err)
+	}
+	magic := make([]byte, len(dawgFileMagic))
+	_, err = io.ReadFull(f, magic)
+	f.Close()
+	if err == nil && string(magic) == dawgFileMagic {
+		return readLexicon(file)
+	}
+	return readDict(file)
+}
+
+func writeDOT(file string, d *DAWG) {
+	f, err := os.Create(file)
+	if err != nil {
+		log.Fatalf("could not create DOT file: %v", err)
+	}
+	opts := DOTOptions{
+		Prefix:   fold(*dotPrefix),
+		MaxDepth: *dotDepth,
+	}
+	if err := d.WriteDOT(f, opts); err != nil {
+		log.Fatalf("could not write DOT file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("could not write DOT file: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFoldCase(t *testing.T) {
+	Convey("the case follows the alphabet", t, func() {
+		lower := MustAlphabet("a", "b", "c")
+		mixed := MustAlphabet("a", "B")
+		for _, tc := range []struct {
+			c    CaseFold
+			set  bool
+			a    *Alphabet
+			want CaseFold
+			err  bool
+		}{
+			{LowerCase, false, nil, LowerCase, false},
+			{LowerCase, false, English, UpperCase, false},
+			{LowerCase, false, Spanish, UpperCase, false},
+			{LowerCase, false, lower, LowerCase, false},
+			{LowerCase, false, mixed, KeepCase, false},
+			{UpperCase, true, English, UpperCase, false},
+			{KeepCase, true, English, KeepCase, false},
+			{LowerCase, true, English, LowerCase, true},
+			{UpperCase, true, lower, UpperCase, true},
+		} {
+			got, err := foldCase(tc.c, tc.set, tc.a)
+			So(got, ShouldEqual, tc.want)
+			So(err != nil, ShouldEqual, tc.err)
+		}
+	})
+
+	Convey("a built in alphabet loads a dictionary", t, func() {
+		file := filepath.Join(t.TempDir(), "words")
+		os.WriteFile(file, []byte("cat\ndog\nemu\nfox\ngnu\nhen\nowl\npig\nyak\n"), 0o644)
+
+		So(flag.Set("alphabet", "english"), ShouldBeNil)
+		defer flag.Set("alphabet", "")
+		d := readDict(file)
+		So(d.Stats().Words, ShouldEqual, 9)
+		So(d.Contains("YAK"), ShouldBeTrue)
+		So(fold("yak"), ShouldEqual, "YAK")
+	})
+
+	Convey("patterns are split into the tiles of the alphabet", t, func() {
+		file := filepath.Join(t.TempDir(), "words")
+		os.WriteFile(file, []byte("chico\ncoche\ncalle\nllama\ncat\n"), 0o644)
+
+		So(flag.Set("alphabet", "spanish"), ShouldBeNil)
+		defer flag.Set("alphabet", "")
+		d := readDict(file)
+		match := func(pattern string) []string {
+			ret := []string{}
+			for w := range d.Match(parsePattern(pattern)) {
+				ret = append(ret, show(w))
+			}
+			return ret
+		}
+		So(match("c?t"), ShouldResemble, []string{"CAT"})
+		So(match("?ico"), ShouldResemble, []string{"CHICO"})
+		So(match("*[ll]*"), ShouldResemble, []string{"CALLE", "LLAMA"})
+		So(match("[aeiou]*"), ShouldBeEmpty)
+		So(match("*"), ShouldHaveLength, 5)
+	})
+}
+
+func TestSorted(t *testing.T) {
+	Convey("sorted dictionaries are sorted again once folded", t, func() {
+		file := filepath.Join(t.TempDir(), "words")
+		os.WriteFile(file, []byte("Apple\nBanana\napple\ncherry\n"), 0o644)
+
+		So(flag.Set("sorted", "true"), ShouldBeNil)
+		defer flag.Set("sorted", "false")
+		d := readDict(file)
+		So(slices.Collect(d.Words()), ShouldResemble, []string{"apple", "banana", "cherry"})
+	})
+}
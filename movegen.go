@@ -0,0 +1,143 @@
+package main
+
+import "unicode/utf8"
+
+// Move is a play of Word starting at X, Y and running in direction
+// Dir, along with what it scores and what it leaves on the rack.
+type Move struct {
+	X, Y int
+	Dir  Direction
+	// Word has a tile for every square the play covers, including
+	// those already on the board.
+	Word string
+	// Tiles is Word with the squares already on the board given as
+	// PlayedThrough, leaving just the tiles played from the rack.
+	// Blanks are played as the letter they stand for; see Blank.
+	Tiles string
+	// Score is what the play scores, counting the words it forms
+	// down through its new tiles and any bingo bonus.
+	Score int
+	// Words are the words the play forms, the main word first, with
+	// blanks as the letters they were played as.
+	Words []string
+	// Leave is what's left on the rack after the play.
+	Leave Rack
+	// Equity is what the play is worth, counting its Leave as well as
+	// its Score; see BestMoves.
+	Equity float64
+}
+
+// GenerateMoves returns every legal play on b using tiles from ra, both
+// across and down, scored with the points in ts. A single tile that
+// makes words in both directions is only returned once, as a play
+// across.
+func GenerateMoves(b *Board, ra Rack, lex Lexicon, ts *TileSet) []Move {
+	ret := []Move{}
+	singles := map[[3]int]bool{}
+	for y := range b.Rows {
+		for m := range b.GenerateRowMoves(y, ra, lex) {
+			if utf8.RuneCountInString(m.Word) < 2 {
+				continue
+			}
+			if x, r, ok := b.single(m); ok {
+				singles[[3]int{x, y, int(r)}] = true
+			}
+			ret = append(ret, b.scored(ts, m))
+		}
+	}
+
+	t := b.Transpose()
+	for x := range t.Rows {
+		for m := range t.GenerateRowMoves(x, ra, lex) {
+			if utf8.RuneCountInString(m.Word) < 2 {
+				continue
+			}
+			if y, r, ok := t.single(m); ok && singles[[3]int{x, y, int(r)}] {
+				continue
+			}
+			ret = append(ret, t.scored(ts, m).transpose())
+		}
+	}
+	return ret
+}
+
+// Score returns what m scores on b with the points in ts.
+func (b *Board) Score(ts *TileSet, m Move) int {
+	if m.Dir == Down {
+		return b.ScoreDown(ts, m.X, m.Y, m.Word)
+	}
+	return b.ScoreAcross(ts, m.X, m.Y, m.Word)
+}
+
+// WordsFormed returns the words m forms on b, the main word first,
+// with blanks as the letters they were played as.
+func (b *Board) WordsFormed(m Move) []string {
+	if m.Dir == Down {
+		return b.Transpose().WordsFormed(m.transpose())
+	}
+	placed := []rune(m.Word)
+	played := []int{}
+	for i, t := range placed {
+		if on := b.Rows[m.Y][m.X+i]; on != Empty {
+			placed[i] = on
+		} else if t != PlayedThrough {
+			played = append(played, i)
+		}
+	}
+	words, _ := b.formedWords(m.X, m.Y, placed, played)
+	ret := make([]string, len(words))
+	for i, w := range words {
+		ret[i] = letterString(w)
+	}
+	return ret
+}
+
+// scored returns m with its Score and Words filled in.
+func (b *Board) scored(ts *TileSet, m Move) Move {
+	m.Score = b.Score(ts, m)
+	m.Words = b.WordsFormed(m)
+	return m
+}
+
+// rowMove returns the play across row y of word, which ends just
+// before end, with ra holding what's left on the rack.
+func (b *Board) rowMove(end, y int, word string, ra Rack) Move {
+	x := end - utf8.RuneCountInString(word)
+	tiles := []rune(word)
+	for i := range tiles {
+		if b.Rows[y][x+i] != Empty {
+			tiles[i] = PlayedThrough
+		}
+	}
+	leave := Rack{}
+	for t, n := range ra {
+		if n > 0 {
+			leave[t] = n
+		}
+	}
+	return Move{X: x, Y: y, Dir: Across, Word: word, Tiles: string(tiles), Leave: leave}
+}
+
+// transpose returns m as it is on the transposed board.
+func (m Move) transpose() Move {
+	m.X, m.Y = m.Y, m.X
+	if m.Dir == Across {
+		m.Dir = Down
+	} else {
+		m.Dir = Across
+	}
+	return m
+}
+
+// single returns where the only new tile of the play across m goes,
+// and what it is, if m plays exactly one tile.
+func (b *Board) single(m Move) (x int, r rune, ok bool) {
+	n := 0
+	for i, t := range []rune(m.Word) {
+		if b.Rows[m.Y][m.X+i] == Empty {
+			x, r = m.X+i, t
+			n++
+		}
+	}
+	return x, r, n == 1
+}
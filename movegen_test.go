@@ -0,0 +1,188 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// moveSet returns moves as a set.
+func moveSet(moves []Move) map[moveKey]bool {
+	ret := map[moveKey]bool{}
+	for _, m := range moves {
+		ret[m.key()] = true
+	}
+	return ret
+}
+
+// bruteForceMoves finds every legal play of a word in dict on b by
+// trying them all at every square with ValidatePlay.
+func bruteForceMoves(b *Board, ra Rack, dict []string, j Judge) map[moveKey]bool {
+	ret := map[moveKey]bool{}
+	singles := map[[3]int]bool{}
+	for _, dir := range []Direction{Across, Down} {
+		t := b
+		if dir == Down {
+			t = b.Transpose()
+		}
+		for y, row := range t.Rows {
+			for x := range row {
+				for _, w := range dict {
+					end := x + len(w)
+					// The whole word, not part of a longer one.
+					if (x > 0 && row[x-1] != Empty) || (end < len(row) && row[end] != Empty) {
+						continue
+					}
+					if t.ValidatePlay(ra, x, y, Across, w, j) != nil {
+						continue
+					}
+					// Played through tiles are as they are on the
+					// board, blanks and all.
+					tiles := []rune(w)
+					for i := range tiles {
+						if row[x+i] != Empty {
+							tiles[i] = row[x+i]
+						}
+					}
+					w := string(tiles)
+
+					tx, r, single := t.single(Move{X: x, Y: y, Dir: Across, Word: w})
+					if dir == Across {
+						if single {
+							singles[[3]int{tx, y, int(r)}] = true
+						}
+						ret[moveKey{x, y, Across, w}] = true
+					} else if !single || !singles[[3]int{y, tx, int(r)}] {
+						ret[moveKey{y, x, Down, w}] = true
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func TestGenerateMoves(t *testing.T) {
+	dict := append([]string{"AD", "OD", "DO", "ODE", "TOE", "NOTE", "TONE", "ETA", "NOD", "DOE", "TOED"}, gameWords...)
+	d := NewDAWG()
+	for _, w := range dict {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	Convey("first move", t, func() {
+		b := NewBoard(StandardLayout)
+		ra := Rack{'N': 1, 'O': 1, 'T': 1, 'E': 1}
+		moves := moveSet(GenerateMoves(b, ra, d, EnglishTiles))
+		So(moves[moveKey{7, 7, Across, "NOTE"}], ShouldBeTrue)
+		So(moves[moveKey{4, 7, Across, "NOTE"}], ShouldBeTrue)
+		So(moves[moveKey{7, 4, Down, "TONE"}], ShouldBeTrue)
+		So(moves[moveKey{7, 8, Across, "NOTE"}], ShouldBeFalse)
+		So(moves[moveKey{3, 7, Across, "NOTE"}], ShouldBeFalse)
+		So(moves, ShouldResemble, bruteForceMoves(b, ra, dict, d))
+	})
+
+	Convey("hooks in every direction", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "NOT")
+		moves := moveSet(GenerateMoves(b, Rack{'E': 1, 'D': 1}, d, EnglishTiles))
+		So(moves[moveKey{7, 7, Across, "NOTE"}], ShouldBeTrue)
+		So(moves[moveKey{8, 6, Down, "DO"}], ShouldBeTrue)
+		So(moves[moveKey{8, 7, Down, "OD"}], ShouldBeTrue)
+		So(moves[moveKey{8, 7, Down, "ODE"}], ShouldBeTrue)
+		So(moves[moveKey{9, 7, Down, "TOE"}], ShouldBeFalse)
+	})
+
+	Convey("single tiles are found once", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "NO")
+		b.PlaceAcross(9, 8, "O")
+		moves := moveSet(GenerateMoves(b, Rack{'D': 1}, d, EnglishTiles))
+
+		// A D at 9, 7 makes NOD across and DO down.
+		So(moves[moveKey{7, 7, Across, "NOD"}], ShouldBeTrue)
+		So(moves[moveKey{9, 7, Down, "DO"}], ShouldBeFalse)
+		// A D at 8, 8 makes DO across and OD down.
+		So(moves[moveKey{8, 8, Across, "DO"}], ShouldBeTrue)
+		So(moves[moveKey{8, 7, Down, "OD"}], ShouldBeFalse)
+		So(moves, ShouldResemble, bruteForceMoves(b, Rack{'D': 1}, dict, d))
+	})
+
+	Convey("agrees with brute force", t, func() {
+		b := guyVsMacBoard()
+		for _, ra := range []Rack{
+			{'A': 1, 'E': 1, 'N': 1, 'O': 1, 'R': 1, 'T': 1, 'D': 1},
+			{'S': 1, 'E': 1, 'C': 1, 'N': 1, 'F': 1},
+		} {
+			So(moveSet(GenerateMoves(b, ra, d, EnglishTiles)), ShouldResemble, bruteForceMoves(b, ra, dict, d))
+		}
+	})
+	Convey("moves are scored with what they leave", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "NO")
+		b.PlaceAcross(9, 8, "O")
+		var nod Move
+		for _, m := range GenerateMoves(b, Rack{'D': 1, 'E': 1}, d, EnglishTiles) {
+			if m.key() == (moveKey{7, 7, Across, "NOD"}) {
+				nod = m
+			}
+		}
+		So(nod.Tiles, ShouldEqual, "..D")
+		So(nod.Words, ShouldResemble, []string{"NOD", "DO"})
+		So(nod.Score, ShouldEqual, 4+3)
+		So(nod.Leave, ShouldResemble, Rack{'E': 1})
+
+		// Down moves are given where they start, like across ones.
+		b = NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "NOT")
+		for _, m := range GenerateMoves(b, Rack{'E': 1, 'D': 1}, d, EnglishTiles) {
+			if m.key() == (moveKey{8, 7, Down, "ODE"}) {
+				So(m.Tiles, ShouldEqual, ".DE")
+				So(m.Words, ShouldResemble, []string{"ODE"})
+				So(m.Score, ShouldEqual, b.ScoreDown(EnglishTiles, 8, 7, "ODE"))
+				So(m.Leave, ShouldResemble, Rack{})
+			}
+		}
+	})
+
+	Convey("bingos", t, func() {
+		b := NewBoard(StandardLayout)
+		ra := Rack{'O': 1, 'U': 1, 'T': 1, 'D': 1, 'R': 1, 'E': 1, 'W': 1}
+		moves := GenerateMoves(b, ra, d, EnglishTiles)
+		found := false
+		for _, m := range moves {
+			if m.key() == (moveKey{1, 7, Across, "OUTDREW"}) {
+				found = true
+				// 12 doubled on the start square, and 50 for using
+				// every tile.
+				So(m.Score, ShouldEqual, 12*2+50)
+			}
+		}
+		So(found, ShouldBeTrue)
+	})
+
+	Convey("blanks", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "NOT")
+		moves := GenerateMoves(b, Rack{Empty: 1}, d, EnglishTiles)
+		keys := moveSet(moves)
+		So(keys[moveKey{7, 7, Across, "NOT" + string(Blank('E'))}], ShouldBeTrue)
+		So(keys[moveKey{8, 6, Down, string(Blank('D')) + "O"}], ShouldBeTrue)
+		So(keys[moveKey{7, 7, Across, "NOTE"}], ShouldBeFalse)
+		for _, m := range moves {
+			if m.key() == (moveKey{7, 7, Across, "NOT" + string(Blank('E'))}) {
+				So(m.Tiles, ShouldEqual, "..."+string(Blank('E')))
+				So(m.Words, ShouldResemble, []string{"NOTE"})
+				So(m.Score, ShouldEqual, 3)
+				So(m.Leave, ShouldResemble, Rack{})
+			}
+			So(b.ValidatePlay(Rack{Empty: 1}, m.X, m.Y, m.Dir, m.Word, d), ShouldBeNil)
+		}
+
+		// The GADDAG uses blanks the same way.
+		g := BuildGADDAG(dict)
+		for y := range b.Rows {
+			So(playSet(b.GenerateRowMovesGADDAG(y, Rack{Empty: 1, 'D': 1}, g)), ShouldResemble, playSet(b.GenerateRowMoves(y, Rack{Empty: 1, 'D': 1}, d)))
+		}
+	})
+}
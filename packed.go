@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Node is a state in a word graph, as seen by the move generator.
+type Node interface {
+	// IsTerminal reports whether the path to this node spells a word.
+	IsTerminal() bool
+	// Next returns the node reached by r, or nil if there is none.
+	Next(r rune) Node
+	// Edges yields each outgoing edge's rune and destination.
+	Edges() iter.Seq2[rune, Node]
+}
+
+// Lexicon is a word graph the move generator can search.
+type Lexicon interface {
+	Judge
+	Root() Node
+}
+
+func (d *DAWG) Root() Node {
+	return d
+}
+
+func (d *DAWG) IsTerminal() bool {
+	return d.Terminal
+}
+
+func (d *DAWG) Next(r rune) Node {
+	if next, ok := d.Edge[r]; ok {
+		return next
+	}
+	return nil
+}
+
+func (d *DAWG) Edges() iter.Seq2[rune, Node] {
+	return func(yield func(rune, Node) bool) {
+		for r, next := range d.Edge {
+			if !yield(r, next) {
+				return
+			}
+		}
+	}
+}
+
+// PackedDAWG is a read-only DAWG with every edge packed into a single
+// uint32, after the KWG layout used by Quackle and Macondo. A node is
+// the run of its outgoing edges, stored contiguously in Edges and
+// sorted by letter, the last one flagged with packedLast. Each edge
+// holds its letter's index in Alphabet, whether the word spelled by
+// following it is accepted, and the index of the first edge of the
+// node it leads to, or 0 if that node has no edges.
+//
+// Edges[0] is not a real edge: it points to the root's edges, and is
+// flagged packedTerminal if the empty string is accepted.
+type PackedDAWG struct {
+	Edges    []uint32
+	Alphabet []rune
+
+	// Index+1 into Alphabet, 0 if absent. Letters below 256 avoid
+	// the map entirely.
+	latin  [256]uint8
+	others map[rune]uint32
+}
+
+const (
+	packedChildMask = 1<<22 - 1
+	packedLast      = 1 << 22
+	packedTerminal  = 1 << 23
+	packedLetter    = 24
+	maxPackedEdges  = packedChildMask + 1
+)
+
+// Pack freezes the graph rooted at d into a PackedDAWG. Nodes shared
+// in d, e.g. by Minimize, are shared in the result too. It returns an
+// error if d has too many edges or letters to pack.
+func (d *DAWG) Pack() (*PackedDAWG, error) {
+	p := &PackedDAWG{others: map[rune]uint32{}}
+
+	// Lay out each node with edges breadth first.
+	starts := map[*DAWG]uint32{}
+	order := []*DAWG{}
+	size := 1
+	add := func(n *DAWG) {
+		if _, ok := starts[n]; ok || len(n.Edge) == 0 {
+			return
+		}
+		starts[n] = uint32(size)
+		size += len(n.Edge)
+		order = append(order, n)
+	}
+	add(d)
+	seen := map[rune]bool{}
+	for i := 0; i < len(order); i++ {
+		for r, child := range order[i].Edge {
+			if !seen[r] {
+				seen[r] = true
+				p.Alphabet = append(p.Alphabet, r)
+			}
+			add(child)
+		}
+	}
+	if size > maxPackedEdges {
+		return nil, fmt.Errorf("graph has %d edges, packing allows %d", size, maxPackedEdges)
+	}
+	if len(p.Alphabet) > 255 {
+		return nil, fmt.Errorf("graph has %d letters, packing allows %d", len(p.Alphabet), 255)
+	}
+	slices.Sort(p.Alphabet)
+	for i, r := range p.Alphabet {
+		if r < rune(len(p.latin)) {
+			p.latin[r] = uint8(i + 1)
+		} else {
+			p.others[r] = uint32(i + 1)
+		}
+	}
+
+	p.Edges = make([]uint32, size)
+	p.Edges[0] = starts[d] | packedLast
+	if d.Terminal {
+		p.Edges[0] |= packedTerminal
+	}
+	for _, n := range order {
+		i := starts[n]
+		runes := sortedEdges(n)
+		for j, r := range runes {
+			child := n.Edge[r]
+			l, _ := p.letter(r)
+			e := l<<packedLetter | starts[child]
+			if child.Terminal {
+				e |= packedTerminal
+			}
+			if j == len(runes)-1 {
+				e |= packedLast
+			}
+			p.Edges[i+uint32(j)] = e
+		}
+	}
+	return p, nil
+}
+
+// letter returns the index of r in p.Alphabet.
+func (p *PackedDAWG) letter(r rune) (uint32, bool) {
+	var l uint32
+	if r >= 0 && r < rune(len(p.latin)) {
+		l = uint32(p.latin[r])
+	} else {
+		l = p.others[r]
+	}
+	return l - 1, l != 0
+}
+
+// follow returns the edge for letter l leaving the node whose edges
+// start at i.
+func (p *PackedDAWG) follow(i, l uint32) (uint32, bool) {
+	if i == 0 {
+		return 0, false
+	}
+	for ; ; i++ {
+		e := p.Edges[i]
+		switch el := e >> packedLetter; {
+		case el == l:
+			return e, true
+		case el > l:
+			// Siblings are sorted, so l isn't here.
+			return 0, false
+		}
+		if e&packedLast != 0 {
+			return 0, false
+		}
+	}
+}
+
+// Contains returns true if s reaches a terminal state from the root.
+func (p *PackedDAWG) Contains(s string) bool {
+	e := p.Edges[0]
+	for _, r := range s {
+		l, ok := p.letter(r)
+		if !ok {
+			return false
+		}
+		if e, ok = p.follow(e&packedChildMask, l); !ok {
+			return false
+		}
+	}
+	return e&packedTerminal != 0
+}
+
+func (p *PackedDAWG) Root() Node {
+	return packedNode{p, p.Edges[0]}
+}
+
+// Size returns the number of bytes used by the packed edges.
+func (p *PackedDAWG) Size() int {
+	return 4 * len(p.Edges)
+}
+
+// packedNode is the node reached by the edge e.
+type packedNode struct {
+	p *PackedDAWG
+	e uint32
+}
+
+func (n packedNode) IsTerminal() bool {
+	return n.e&packedTerminal != 0
+}
+
+func (n packedNode) Next(r rune) Node {
+	l, ok := n.p.letter(r)
+	if !ok {
+		return nil
+	}
+	if e, ok := n.p.follow(n.e&packedChildMask, l); ok {
+		return packedNode{n.p, e}
+	}
+	return nil
+}
+
+func (n packedNode) Edges() iter.Seq2[rune, Node] {
+	return func(yield func(rune, Node) bool) {
+		i := n.e & packedChildMask
+		if i == 0 {
+			return
+		}
+		for ; ; i++ {
+			e := n.p.Edges[i]
+			if !yield(n.p.Alphabet[e>>packedLetter], packedNode{n.p, e}) {
+				return
+			}
+			if e&packedLast != 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPack(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range append(gameWords, testWords...) {
+		d.Add(w)
+	}
+	d.Minimize()
+	p, err := d.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("contains the same words", t, func() {
+		for _, w := range append(gameWords, testWords...) {
+			So(p.Contains(w), ShouldBeTrue)
+		}
+		for _, w := range []string{"", "C", "CARTE", "DARTS", "OOFS", "ZZZ", "é"} {
+			So(p.Contains(w), ShouldEqual, d.Contains(w))
+		}
+	})
+
+	Convey("one edge per DAWG edge", t, func() {
+		edges := 0
+		Visitor{}.Traverse(d, func(e rune, g *DAWG) {
+			edges += len(g.Edge)
+		})
+		edges += len(d.Edge)
+		So(len(p.Edges), ShouldEqual, edges+1)
+	})
+
+	Convey("same edges", t, func() {
+		var walk func(a, b Node, prefix string)
+		walk = func(a, b Node, prefix string) {
+			So(b.IsTerminal(), ShouldEqual, a.IsTerminal())
+			want := map[rune]Node{}
+			for r, n := range a.Edges() {
+				want[r] = n
+			}
+			got := 0
+			last := rune(0)
+			for r, n := range b.Edges() {
+				So(r, ShouldBeGreaterThan, last)
+				last = r
+				So(want[r], ShouldNotBeNil)
+				walk(want[r], n, prefix+string(r))
+				got++
+			}
+			So(got, ShouldEqual, len(want))
+			So(b.Next('!'), ShouldBeNil)
+		}
+		walk(d.Root(), p.Root(), "")
+	})
+
+	Convey("empty graph", t, func() {
+		p, err := NewDAWG().Pack()
+		So(err, ShouldBeNil)
+		So(p.Contains(""), ShouldBeFalse)
+		So(p.Contains("A"), ShouldBeFalse)
+		e, err := (&DAWG{Terminal: true}).Pack()
+		So(err, ShouldBeNil)
+		So(e.Contains(""), ShouldBeTrue)
+	})
+
+	Convey("too many letters", t, func() {
+		big := NewDAWG()
+		for r := rune(0x100); r < 0x200; r++ {
+			big.Add(string(r))
+		}
+		_, err := big.Pack()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "256 letters")
+	})
+
+	Convey("move generation", t, func() {
+		b := guyVsMacBoard()
+		ra := Rack{'A': 1, 'E': 1, 'N': 1, 'O': 1, 'R': 1, 'T': 1, 'D': 1}
+		So(b.CrossChecks(8, 6, p), ShouldResemble, b.CrossChecks(8, 6, d))
+		for y := range b.Rows {
+			So(playSet(b.GenerateRowMoves(y, ra, p)), ShouldResemble, playSet(b.GenerateRowMoves(y, ra, d)))
+		}
+	})
+}
+
+// benchWords returns the words in -dict, skipping the benchmark if
+// it can't be read.
+func benchWords(b *testing.B) []string {
+	byts, err := os.ReadFile(*dictFile)
+	if err != nil {
+		b.Skipf("reading dict: %v", err)
+	}
+	ret := []string{}
+	for _, line := range strings.Split(string(byts), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			ret = append(ret, line)
+		}
+	}
+	return ret
+}
+
+func heapInUse() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+func BenchmarkContains(b *testing.B) {
+	words := benchWords(b)
+
+	before := heapInUse()
+	d := NewDAWG()
+	for _, w := range words {
+		d.Add(w)
+	}
+	d.Minimize()
+	mapBytes := heapInUse() - before
+	p, err := d.Pack()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("map", func(b *testing.B) {
+		b.ReportMetric(float64(mapBytes)/float64(len(words)), "bytes/word")
+		for n := 0; n < b.N; n++ {
+			d.Contains(words[n%len(words)])
+		}
+	})
+
+	b.Run("packed", func(b *testing.B) {
+		b.ReportMetric(float64(p.Size())/float64(len(words)), "bytes/word")
+		for n := 0; n < b.N; n++ {
+			p.Contains(words[n%len(words)])
+		}
+	})
+}
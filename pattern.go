@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Pattern is a crossword-style word pattern:
+//
+//	?        any single letter
+//	*        any run of letters, including none
+//	[AEIOU]  any one of the listed letters
+//	[^AEIOU] any one letter not listed
+//
+// Any other rune matches only itself.
+type Pattern struct {
+	tokens []patternToken
+}
+
+type patternToken struct {
+	kind    patternKind
+	r       rune
+	set     map[rune]bool
+	negated bool
+}
+
+type patternKind int
+
+const (
+	literal patternKind = iota
+	anyOne
+	anyRun
+	class
+)
+
+// maxPatternTokens bounds the size of a pattern so the set of
+// positions it can be in fits in a uint64.
+const maxPatternTokens = 63
+
+// ParsePattern parses s into a Pattern.
+func ParsePattern(s string) (*Pattern, error) {
+	return ParseTilePattern(s, nil)
+}
+
+// ParseTilePattern is like ParsePattern, but for words spelled with the
+// tiles of a: the letters between wildcards, and those listed in a
+// class, are split into tiles as Alphabet.Encode splits them. A class
+// of one tile, as in [C]H, makes a split explicit. If a is nil each
+// rune is a letter of its own.
+func ParseTilePattern(s string, a *Alphabet) (*Pattern, error) {
+	p := &Pattern{}
+	encode := func(letters string) ([]rune, error) {
+		if a == nil {
+			return []rune(letters), nil
+		}
+		tiles, err := a.Encode(letters)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", s, err)
+		}
+		return []rune(tiles), nil
+	}
+	var run []rune
+	flush := func() error {
+		tiles, err := encode(string(run))
+		for _, t := range tiles {
+			p.tokens = append(p.tokens, patternToken{kind: literal, r: t})
+		}
+		run = run[:0]
+		return err
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !strings.ContainsRune("?*[", r) {
+			run = append(run, r)
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		switch r {
+		case '?':
+			p.tokens = append(p.tokens, patternToken{kind: anyOne})
+		case '*':
+			// Consecutive stars match the same as one.
+			if n := len(p.tokens); n == 0 || p.tokens[n-1].kind != anyRun {
+				p.tokens = append(p.tokens, patternToken{kind: anyRun})
+			}
+		case '[':
+			end := strings.IndexRune(string(runes[i+1:]), ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ at %d in pattern %q", i, s)
+			}
+			body := []rune(string(runes[i+1:])[:end])
+			i += len(body) + 1
+			t := patternToken{kind: class, set: map[rune]bool{}}
+			if len(body) > 0 && body[0] == '^' {
+				t.negated = true
+				body = body[1:]
+			}
+			if len(body) == 0 {
+				return nil, fmt.Errorf("empty [] in pattern %q", s)
+			}
+			tiles, err := encode(string(body))
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range tiles {
+				t.set[c] = true
+			}
+			p.tokens = append(p.tokens, t)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) > maxPatternTokens {
+		return nil, fmt.Errorf("pattern %q is too long", s)
+	}
+	return p, nil
+}
+
+func (t patternToken) matches(r rune) bool {
+	switch t.kind {
+	case literal:
+		return r == t.r
+	case class:
+		return t.set[r] != t.negated
+	}
+	return true
+}
+
+// positions is a set of indexes into Pattern.tokens.
+type positions uint64
+
+// closure adds to s every position reachable by letting stars match
+// nothing.
+func (p *Pattern) closure(s positions) positions {
+	for i, t := range p.tokens {
+		if s&(1<<i) != 0 && t.kind == anyRun {
+			s |= 1 << (i + 1)
+		}
+	}
+	return s
+}
+
+// step returns the positions reached from s by consuming r.
+func (p *Pattern) step(s positions, r rune) positions {
+	var ret positions
+	for i, t := range p.tokens {
+		if s&(1<<i) == 0 || !t.matches(r) {
+			continue
+		}
+		if t.kind == anyRun {
+			ret |= 1 << i
+		} else {
+			ret |= 1 << (i + 1)
+		}
+	}
+	return p.closure(ret)
+}
+
+func (p *Pattern) done(s positions) bool {
+	return s&(1<<len(p.tokens)) != 0
+}
+
+// Match yields every word in d matching p, in lexical order.
+func (d *DAWG) Match(p *Pattern) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var walk func(node *DAWG, prefix []rune, s positions) bool
+		walk = func(node *DAWG, prefix []rune, s positions) bool {
+			if node.Terminal && p.done(s) {
+				if !yield(string(prefix)) {
+					return false
+				}
+			}
+			for _, r := range sortedEdges(node) {
+				next := p.step(s, r)
+				if next == 0 {
+					continue
+				}
+				if !walk(node.Edge[r], append(prefix, r), next) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(d, nil, p.closure(1))
+	}
+}
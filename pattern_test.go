@@ -0,0 +1,102 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMatch(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range append(gameWords, testWords...) {
+		d.Add(w)
+	}
+	d.Add("QUICK")
+	d.Add("QUIRK")
+	d.Add("PIZZA")
+	d.Add("JAZZ")
+	d.Add("ZZZ")
+	d.Minimize()
+
+	match := func(pattern string) []string {
+		p, err := ParsePattern(pattern)
+		So(err, ShouldBeNil)
+		return slices.Collect(d.Match(p))
+	}
+
+	Convey("single letters", t, func() {
+		So(match("Q?I?K"), ShouldResemble, []string{"QUICK", "QUIRK"})
+		So(match("CAR?"), ShouldResemble, []string{"CARE", "CARS", "CART"})
+		So(match("CAR"), ShouldResemble, []string{"CAR"})
+		So(match("??"), ShouldHaveLength, 80)
+		So(match("X"), ShouldBeEmpty)
+	})
+
+	Convey("runs", t, func() {
+		So(match("*ZZ*"), ShouldResemble, []string{"JAZZ", "PIZZA", "ZZZ"})
+		So(match("CAR*"), ShouldResemble, []string{"CAR", "CARE", "CARED", "CARES", "CARS", "CART", "CARTS"})
+		So(match("*ED"), ShouldResemble, []string{"CARED", "DARED", "ED", "TARED"})
+		So(match("**ED"), ShouldResemble, match("*ED"))
+		So(match("*A*A*"), ShouldResemble, []string{"AA", "ALACK", "PAVAN"})
+		So(match("Z*"), ShouldResemble, []string{"ZA", "ZEDS", "ZZZ"})
+	})
+
+	Convey("classes", t, func() {
+		So(match("[AEIOU]X"), ShouldResemble, []string{"AX", "EX", "OX"})
+		So(match("[^AEIOU]A"), ShouldResemble, []string{"FA", "HA", "LA", "MA", "NA", "PA", "TA", "YA", "ZA"})
+		So(match("[CD]ARE?"), ShouldResemble, []string{"CARED", "CARES", "DARED", "DARES"})
+	})
+
+	Convey("in order", t, func() {
+		all := match("*")
+		So(slices.IsSorted(all), ShouldBeTrue)
+		So(len(all), ShouldEqual, len(slices.Compact(all)))
+	})
+
+	Convey("stopping early", t, func() {
+		p, _ := ParsePattern("*")
+		n := 0
+		for range d.Match(p) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		So(n, ShouldEqual, 3)
+	})
+
+	Convey("bad patterns", t, func() {
+		_, err := ParsePattern("[AB")
+		So(err, ShouldNotBeNil)
+		_, err = ParsePattern("A[]")
+		So(err, ShouldNotBeNil)
+		_, err = ParseTilePattern("W?", Spanish)
+		So(err, ShouldNotBeNil)
+		_, err = ParseTilePattern("[AW]", Spanish)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("multi-letter tiles", t, func() {
+		es := NewDAWG()
+		for _, w := range []string{"CHICO", "COCHE", "CALLE", "LLAMA"} {
+			es.Add(Spanish.MustEncode(w))
+		}
+		es.Minimize()
+		match := func(pattern string) []string {
+			p, err := ParseTilePattern(pattern, Spanish)
+			So(err, ShouldBeNil)
+			ret := []string{}
+			for w := range es.Match(p) {
+				ret = append(ret, Spanish.Decode(w))
+			}
+			return ret
+		}
+		So(match("?ICO"), ShouldResemble, []string{"CHICO"})
+		So(match("C*"), ShouldResemble, []string{"CALLE", "COCHE"})
+		So(match("[C]H*"), ShouldBeEmpty)
+		So(match("*[LL]*"), ShouldResemble, []string{"CALLE", "LLAMA"})
+		So(match("[^CH]*"), ShouldResemble, []string{"CALLE", "COCHE", "LLAMA"})
+		So(match("*"), ShouldHaveLength, 4)
+	})
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWordMultipliers(t *testing.T) {
+	// Each play goes across the top row from the left. Tiles already on
+	// the board are given as rows of letters, with . for empty squares.
+	// With English tiles A and E are worth 1 point and B 3.
+	for _, tc := range []struct {
+		name   string
+		layout []string
+		tiles  []string
+		word   string
+		want   int
+	}{
+		{"no premiums", []string{"..."}, nil, "AB", 4},
+		{"DL", []string{"d.."}, nil, "BA", 7},
+		{"DL on the other tile", []string{"d.."}, nil, "AB", 5},
+		{"TL", []string{"t.."}, nil, "BA", 10},
+		{"QL", []string{"q.."}, nil, "BA", 13},
+		{"DW", []string{"D.."}, nil, "AB", 8},
+		{"TW", []string{"T.."}, nil, "AB", 12},
+		{"QW", []string{"Q.."}, nil, "AB", 16},
+		{"DL and DW", []string{"dD."}, nil, "BA", 14},
+		{"TL and DW", []string{"tD."}, nil, "BA", 20},
+		{"DL and TW", []string{"dT."}, nil, "BA", 21},
+		{"TL and TW", []string{"tT."}, nil, "BA", 30},
+		{"DW and DW", []string{"DD."}, nil, "AB", 16},
+		{"DW and TW", []string{"DT."}, nil, "AB", 24},
+		{"TW and TW", []string{"T.T"}, nil, "ABA", 45},
+		{"DW, TW and TW", []string{"DTT"}, nil, "ABA", 90},
+		{"TW, TW and TW", []string{"T.T.T"}, nil, "ABABA", 243},
+		{"QW and QL", []string{"Qq."}, nil, "BB", 60},
+		{"every premium", []string{"dtDT"}, nil, "BBBB", 126},
+		{"played through premiums don't count", []string{"TTT"}, []string{".B."}, "ABA", 45},
+		{
+			"cross words take their square's premium",
+			[]string{"T.T", "..."},
+			[]string{"...", "E.E"},
+			"ABA",
+			45 + (1+1)*3 + (1+1)*3,
+		},
+		{
+			"cross word through a DW and DL",
+			[]string{".D.", ".d."},
+			[]string{"...", ".E."},
+			"ABA",
+			(1+3+1)*2 + (3+1)*2,
+		},
+	} {
+		Convey(tc.name, t, func() {
+			rows := append([]string{}, tc.layout...)
+			rows = append(rows, "+"+strings.Repeat(".", len(rows[0])-1))
+			b := NewBoard(MustLayout(strings.Join(rows, "\n")))
+			for y, row := range tc.tiles {
+				for x, r := range row {
+					if r != '.' {
+						b.Rows[y][x] = r
+					}
+				}
+			}
+			So(b.ScoreAcross(EnglishTiles, 0, 0, tc.word), ShouldEqual, tc.want)
+
+			// Scoring down the transposed board is the same.
+			So(b.Transpose().ScoreDown(EnglishTiles, 0, 0, tc.word), ShouldEqual, tc.want)
+		})
+	}
+
+	Convey("triple-triples on a standard board", t, func() {
+		// 9x: eight tiles from one corner to the middle of the top
+		// edge, through an A already on the board. The O is on a
+		// double letter square.
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(1, 0, "A")
+		// (1 + 1 + 1 + 2 + 1 + 1 + 1 + 1) * 9 + 50
+		So(b.ScoreAcross(EnglishTiles, 0, 0, "EAIOEAEE"), ShouldEqual, 131)
+
+		// 27x: the whole top row, playing seven tiles through eight
+		// already there. P and Z are on the double letters.
+		b = NewBoard(StandardLayout)
+		for _, x := range []int{1, 2, 4, 5, 8, 9, 12, 13} {
+			b.PlaceAcross(x, 0, string("OXYPHENBUTAZONE"[x]))
+		}
+		// (41 + 3 + 10) * 27 + 50
+		So(b.ScoreAcross(EnglishTiles, 0, 0, "OXYPHENBUTAZONE"), ShouldEqual, 1508)
+		So(b.Transpose().ScoreDown(EnglishTiles, 0, 0, "OXYPHENBUTAZONE"), ShouldEqual, 1508)
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+// setOp decides, for a pair of nodes reached by the same path in two
+// graphs, whether the result accepts that path (terminal) and which
+// edges it follows. A nil node means the path isn't in that graph.
+type setOp struct {
+	terminal func(a, b bool) bool
+	// both is true if an edge has to be in both graphs to be
+	// followed, and aOnly if it must at least be in a.
+	both, aOnly bool
+}
+
+var (
+	unionOp        = setOp{terminal: func(a, b bool) bool { return a || b }}
+	intersectionOp = setOp{terminal: func(a, b bool) bool { return a && b }, both: true}
+	differenceOp   = setOp{terminal: func(a, b bool) bool { return a && !b }, aOnly: true}
+)
+
+// Union returns a minimized graph of the words in either a or b.
+func Union(a, b *DAWG) *DAWG {
+	return unionOp.apply(a, b)
+}
+
+// Intersection returns a minimized graph of the words in both a and b.
+func Intersection(a, b *DAWG) *DAWG {
+	return intersectionOp.apply(a, b)
+}
+
+// Difference returns a minimized graph of the words in a but not b.
+func Difference(a, b *DAWG) *DAWG {
+	return differenceOp.apply(a, b)
+}
+
+// apply walks a and b in lockstep, building one result node for each
+// distinct pair of nodes reached, so shared subgraphs are only walked
+// once.
+func (op setOp) apply(a, b *DAWG) *DAWG {
+	ret := op.walk(a, b, map[[2]*DAWG]*DAWG{})
+	if ret == nil {
+		return NewDAWG()
+	}
+	ret.Minimize()
+	return ret
+}
+
+// walk returns the result node for the pair a, b, or nil if it would
+// accept no words.
+func (op setOp) walk(a, b *DAWG, done map[[2]*DAWG]*DAWG) *DAWG {
+	key := [2]*DAWG{a, b}
+	if n, ok := done[key]; ok {
+		return n
+	}
+
+	var aEdges, bEdges map[rune]*DAWG
+	var aTerminal, bTerminal bool
+	if a != nil {
+		aEdges, aTerminal = a.Edge, a.Terminal
+	}
+	if b != nil {
+		bEdges, bTerminal = b.Edge, b.Terminal
+	}
+
+	n := NewDAWG()
+	n.Terminal = op.terminal(aTerminal, bTerminal)
+	follow := func(r rune) {
+		if _, ok := n.Edge[r]; ok {
+			return
+		}
+		if next := op.walk(aEdges[r], bEdges[r], done); next != nil {
+			n.Edge[r] = next
+		}
+	}
+	for r := range aEdges {
+		if !op.both || bEdges[r] != nil {
+			follow(r)
+		}
+	}
+	if !op.both && !op.aOnly {
+		for r := range bEdges {
+			follow(r)
+		}
+	}
+
+	if !n.Terminal && len(n.Edge) == 0 {
+		n = nil
+	}
+	done[key] = n
+	return n
+}
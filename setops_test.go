@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSetOps(t *testing.T) {
+	build := func(words ...string) *DAWG {
+		d := NewDAWG()
+		for _, w := range words {
+			d.Add(w)
+		}
+		d.Minimize()
+		return d
+	}
+	// Roughly, Collins and TWL.
+	a := build("CAR", "CARE", "CARED", "CARES", "QI", "ZA", "ZO", "OK")
+	b := build("CAR", "CARE", "CARS", "QI", "ZA", "QAT")
+
+	Convey("union", t, func() {
+		u := Union(a, b)
+		So(slices.Collect(u.Words()), ShouldResemble,
+			[]string{"CAR", "CARE", "CARED", "CARES", "CARS", "OK", "QAT", "QI", "ZA", "ZO"})
+	})
+
+	Convey("intersection", t, func() {
+		i := Intersection(a, b)
+		So(slices.Collect(i.Words()), ShouldResemble, []string{"CAR", "CARE", "QI", "ZA"})
+	})
+
+	Convey("difference", t, func() {
+		So(slices.Collect(Difference(a, b).Words()), ShouldResemble, []string{"CARED", "CARES", "OK", "ZO"})
+		So(slices.Collect(Difference(b, a).Words()), ShouldResemble, []string{"CARS", "QAT"})
+		So(slices.Collect(Difference(a, a).Words()), ShouldBeEmpty)
+	})
+
+	Convey("minimal", t, func() {
+		u := Union(a, b)
+		m := build(slices.Collect(u.Words())...)
+		So(u.NodeCount(), ShouldEqual, m.NodeCount())
+
+		i := Intersection(a, b)
+		m = build(slices.Collect(i.Words())...)
+		So(i.NodeCount(), ShouldEqual, m.NodeCount())
+	})
+
+	Convey("inputs unchanged", t, func() {
+		Union(a, b)
+		Difference(a, b)
+		So(a.Count(), ShouldEqual, 8)
+		So(b.Count(), ShouldEqual, 6)
+	})
+
+	Convey("empty graphs", t, func() {
+		So(slices.Collect(Union(NewDAWG(), b).Words()), ShouldResemble, slices.Collect(b.Words()))
+		So(slices.Collect(Intersection(NewDAWG(), b).Words()), ShouldBeEmpty)
+		So(slices.Collect(Difference(a, NewDAWG()).Words()), ShouldResemble, slices.Collect(a.Words()))
+	})
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TileSet is the distribution of tiles a game is played with: which
+// tiles there are, how many of each are in the sack and how many points
+// each is worth. Blanks are counted under Empty and are worth nothing.
+type TileSet struct {
+	Alphabet *Alphabet
+	Counts   map[rune]int
+	Points   map[rune]int
+	// Vowels are the tiles that are vowels, for HeuristicLeave.
+	Vowels map[rune]bool
+	// RackSize is how many tiles a player holds, and BingoBonus what
+	// a play using all of them scores on top of its words.
+	RackSize   int
+	BingoBonus int
+}
+
+// The rack size and bingo bonus ReadTileSet gives tile sets.
+const (
+	defaultRackSize   = 7
+	defaultBingoBonus = 50
+)
+
+// BlankTile is how a blank is written in tile set files and racks.
+const BlankTile = "?"
+
+// A blank played as a letter is a tile of its own, the letter offset
+// into Unicode's supplementary private use area, so that it survives
+// being put in a string. This only works for letters in the basic
+// multilingual plane, which is why alphabets can't have tiles outside
+// it.
+const (
+	firstBlank = '\U000F0000'
+	lastBlank  = firstBlank + maxLetter
+	maxLetter  = '\uFFFD'
+)
+
+// Blank returns the tile for a blank played as letter.
+func Blank(letter rune) rune {
+	return firstBlank + letter
+}
+
+// IsBlank returns true if the tile t is a blank.
+func IsBlank(t rune) bool {
+	return t >= firstBlank && t <= lastBlank
+}
+
+// Letter returns the letter the tile t stands for, whether it's a
+// blank or not.
+func Letter(t rune) rune {
+	if IsBlank(t) {
+		return t - firstBlank
+	}
+	return t
+}
+
+// ReadTileSet reads a tile set, one tile per line, from r. Each line
+// holds a tile, how many there are and what each is worth, separated
+// by spaces:
+//
+//	# tile count points
+//	A 9 1
+//	CH 1 5
+//	? 2 0
+//
+// where ? is the blanks. Blank lines and lines starting with # are
+// skipped. The tiles' alphabet is taken from the order they're listed
+// in. Tiles that are A, E, I, O or U, with or without accents, are
+// taken to be the vowels; set Vowels to change that. Racks are of 7
+// tiles, and playing them all scores a bonus of 50.
+func ReadTileSet(r io.Reader) (*TileSet, error) {
+	ts := &TileSet{
+		Counts: map[rune]int{},
+		Points: map[rune]int{},
+		Vowels: map[rune]bool{},
+
+		RackSize:   defaultRackSize,
+		BingoBonus: defaultBingoBonus,
+	}
+	tiles := []string{}
+	counts := []int{}
+	points := []int{}
+	blanks := -1
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: want tile, count and points, got %q", n, line)
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("line %d: bad count %q", n, fields[1])
+		}
+		p, err := strconv.Atoi(fields[2])
+		if err != nil || p < 0 {
+			return nil, fmt.Errorf("line %d: bad points %q", n, fields[2])
+		}
+
+		if fields[0] == BlankTile {
+			if blanks >= 0 {
+				return nil, fmt.Errorf("line %d: blanks listed twice", n)
+			}
+			if p != 0 {
+				return nil, fmt.Errorf("line %d: blanks must be worth 0 points", n)
+			}
+			blanks = count
+			continue
+		}
+		tiles = append(tiles, fields[0])
+		counts = append(counts, count)
+		points = append(points, p)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(tiles) == 0 {
+		return nil, fmt.Errorf("no tiles")
+	}
+
+	a, err := NewAlphabet(tiles...)
+	if err != nil {
+		return nil, err
+	}
+	ts.Alphabet = a
+	for i, r := range a.Letters() {
+		ts.Counts[r] = counts[i]
+		ts.Points[r] = points[i]
+		switch unaccented.Fold(tiles[i]) {
+		case "A", "E", "I", "O", "U":
+			ts.Vowels[r] = true
+		}
+	}
+	if blanks > 0 {
+		ts.Counts[Empty] = blanks
+	}
+	return ts, nil
+}
+
+// unaccented folds tiles to the upper case letters they're written
+// with, without their accents.
+var unaccented = LoadOptions{StripAccents: true, Case: UpperCase}
+
+// withVowels returns ts with tiles as vowels too.
+func withVowels(ts *TileSet, tiles ...string) *TileSet {
+	for _, t := range tiles {
+		ts.Vowels[ts.Alphabet.codes[t]] = true
+	}
+	return ts
+}
+
+// withBingoBonus returns ts with a bingo bonus of bonus.
+func withBingoBonus(ts *TileSet, bonus int) *TileSet {
+	ts.BingoBonus = bonus
+	return ts
+}
+
+// MustTileSet is like ReadTileSet but reads from a string and panics
+// on error.
+func MustTileSet(s string) *TileSet {
+	ts, err := ReadTileSet(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// Value returns the points the tile t is worth, which is nothing if
+// it's a blank.
+func (ts *TileSet) Value(t rune) int {
+	if IsBlank(t) {
+		return 0
+	}
+	return ts.Points[t]
+}
+
+// Size returns the number of tiles in a full sack.
+func (ts *TileSet) Size() int {
+	n := 0
+	for _, c := range ts.Counts {
+		n += c
+	}
+	return n
+}
+
+// Rack returns the rack holding tiles, spelled with ts's alphabet and
+// with ? for blanks, as in "AEIRST?". It's an error for the rack to
+// hold more of a tile than there are in the set.
+func (ts *TileSet) Rack(tiles string) (Rack, error) {
+	ra := Rack{}
+	blanks := strings.Count(tiles, BlankTile)
+	if blanks > 0 {
+		ra[Empty] = blanks
+	}
+	letters, err := ts.Alphabet.Encode(strings.ReplaceAll(tiles, BlankTile, ""))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range letters {
+		ra[r]++
+	}
+	for r, n := range ra {
+		if n > ts.Counts[r] {
+			return nil, fmt.Errorf("rack %q has %d %s, but there are only %d", tiles, n, ts.name(r), ts.Counts[r])
+		}
+	}
+	return ra, nil
+}
+
+// name returns how the tile r is written.
+func (ts *TileSet) name(r rune) string {
+	if r == Empty {
+		return BlankTile
+	}
+	return ts.Alphabet.Decode(string(r))
+}
+
+// Points returns the total value of the tiles on r, as is deducted
+// from a player left holding them at the end of a game.
+func (r Rack) Points(ts *TileSet) int {
+	ret := 0
+	for t, n := range r {
+		ret += ts.Points[t] * n
+	}
+	return ret
+}
+
+var (
+	EnglishTiles = MustTileSet(`
+A 9 1
+B 2 3
+C 2 3
+D 4 2
+E 12 1
+F 2 4
+G 3 2
+H 2 4
+I 9 1
+J 1 8
+K 1 5
+L 4 1
+M 2 3
+N 6 1
+O 8 1
+P 2 3
+Q 1 10
+R 6 1
+S 4 1
+T 6 1
+U 4 1
+V 2 4
+W 2 4
+X 1 8
+Y 2 4
+Z 1 10
+? 2 0
+`)
+
+	FrenchTiles = MustTileSet(`
+A 9 1
+B 2 3
+C 2 3
+D 3 2
+E 15 1
+F 2 4
+G 2 2
+H 2 4
+I 8 1
+J 1 8
+K 1 10
+L 5 1
+M 3 2
+N 6 1
+O 6 1
+P 2 3
+Q 1 8
+R 6 1
+S 6 1
+T 6 1
+U 6 1
+V 2 4
+W 1 10
+X 1 10
+Y 1 10
+Z 1 10
+? 2 0
+`)
+
+	GermanTiles = MustTileSet(`
+A 5 1
+B 2 3
+C 2 4
+D 4 1
+E 15 1
+F 2 4
+G 3 2
+H 4 2
+I 6 1
+J 1 6
+K 2 4
+L 3 2
+M 4 3
+N 9 1
+O 3 2
+P 1 4
+Q 1 10
+R 6 1
+S 7 1
+T 6 1
+U 6 1
+V 1 6
+W 1 3
+X 1 8
+Y 1 10
+Z 1 3
+Ä 1 6
+Ö 1 8
+Ü 1 6
+? 2 0
+`)
+
+	SpanishTiles = MustTileSet(`
+A 12 1
+B 2 3
+C 4 3
+CH 1 5
+D 5 2
+E 12 1
+F 1 4
+G 2 2
+H 2 4
+I 6 1
+J 1 8
+L 4 1
+LL 1 8
+M 2 3
+N 5 1
+Ñ 1 8
+O 9 1
+P 2 3
+Q 1 5
+R 5 1
+RR 1 8
+S 6 1
+T 4 1
+U 5 1
+V 1 4
+X 1 8
+Y 1 4
+Z 1 10
+? 2 0
+`)
+
+	PolishTiles = withVowels(MustTileSet(`
+A 9 1
+Ą 1 5
+B 2 3
+C 3 2
+Ć 1 6
+D 3 2
+E 7 1
+Ę 1 5
+F 1 5
+G 2 3
+H 2 3
+I 8 1
+J 2 3
+K 3 2
+L 3 2
+Ł 2 3
+M 3 2
+N 5 1
+Ń 1 7
+O 6 1
+Ó 1 5
+P 3 2
+R 4 1
+S 4 1
+Ś 1 5
+T 3 2
+U 2 3
+W 4 1
+Y 4 2
+Z 5 1
+Ź 1 9
+Ż 1 5
+? 2 0
+`), "Y")
+
+	WordsWithFriendsTiles = withBingoBonus(MustTileSet(`
+A 9 1
+B 2 4
+C 2 4
+D 5 2
+E 13 1
+F 2 4
+G 3 3
+H 4 3
+I 8 1
+J 1 10
+K 1 5
+L 4 2
+M 2 4
+N 5 2
+O 8 1
+P 2 4
+Q 1 10
+R 6 1
+S 5 1
+T 7 1
+U 4 2
+V 2 5
+W 2 4
+X 1 8
+Y 2 3
+Z 1 10
+? 2 0
+`), 35)
+)
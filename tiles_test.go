@@ -0,0 +1,179 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTileSet(t *testing.T) {
+	Convey("built-ins", t, func() {
+		So(EnglishTiles.Size(), ShouldEqual, 100)
+		So(FrenchTiles.Size(), ShouldEqual, 102)
+		So(GermanTiles.Size(), ShouldEqual, 102)
+		So(SpanishTiles.Size(), ShouldEqual, 100)
+		So(PolishTiles.Size(), ShouldEqual, 100)
+		So(WordsWithFriendsTiles.Size(), ShouldEqual, 104)
+
+		for _, ts := range []*TileSet{EnglishTiles, FrenchTiles, GermanTiles, SpanishTiles, PolishTiles, WordsWithFriendsTiles} {
+			So(ts.Counts[Empty], ShouldEqual, 2)
+			So(ts.Points[Empty], ShouldEqual, 0)
+		}
+
+		So(EnglishTiles.Points['Q'], ShouldEqual, 10)
+		So(FrenchTiles.Points['K'], ShouldEqual, 10)
+		So(GermanTiles.Points['Ö'], ShouldEqual, 8)
+		So(PolishTiles.Points['Ź'], ShouldEqual, 9)
+		So(WordsWithFriendsTiles.Points['J'], ShouldEqual, 10)
+
+		ch := SpanishTiles.Alphabet.MustEncode("CH")
+		So(ch, ShouldEqual, Spanish.MustEncode("CH"))
+		So(SpanishTiles.Points[[]rune(ch)[0]], ShouldEqual, 5)
+	})
+
+	Convey("reading", t, func() {
+		ts, err := ReadTileSet(strings.NewReader("# tile count points\nA 3 1\n\nCH 1 5\n? 1 0\n"))
+		So(err, ShouldBeNil)
+		So(ts.Size(), ShouldEqual, 5)
+		So(ts.Alphabet.Letters(), ShouldHaveLength, 2)
+		So(ts.Counts, ShouldResemble, map[rune]int{'A': 3, firstMultiTile: 1, Empty: 1})
+		So(ts.Points, ShouldResemble, map[rune]int{'A': 1, firstMultiTile: 5})
+
+		for _, bad := range []string{
+			"",
+			"A 1",
+			"A one 1",
+			"A 1 -1",
+			"A 1 1\nA 2 1",
+			"A 1 1\n? 2 1",
+			"A 1 1\n? 2 0\n? 2 0",
+		} {
+			_, err := ReadTileSet(strings.NewReader(bad))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("racks", t, func() {
+		ra, err := EnglishTiles.Rack("QUIZ??E")
+		So(err, ShouldBeNil)
+		So(ra, ShouldResemble, Rack{'Q': 1, 'U': 1, 'I': 1, 'Z': 1, 'E': 1, Empty: 2})
+		So(ra.Points(EnglishTiles), ShouldEqual, 23)
+		So(ra.Points(WordsWithFriendsTiles), ShouldEqual, 24)
+
+		ra, err = SpanishTiles.Rack("CHLLA?")
+		So(err, ShouldBeNil)
+		So(ra.Points(SpanishTiles), ShouldEqual, 14)
+
+		_, err = EnglishTiles.Rack("QQ")
+		So(err, ShouldNotBeNil)
+		_, err = EnglishTiles.Rack("???")
+		So(err, ShouldNotBeNil)
+		_, err = EnglishTiles.Rack("Ñ")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("sacks", t, func() {
+		for _, ts := range []*TileSet{EnglishTiles, SpanishTiles, WordsWithFriendsTiles} {
+			s := NewSack(ts)
+			n := 0
+			for len(s) > 0 {
+				s.Draw()
+				n++
+			}
+			So(n, ShouldEqual, ts.Size())
+		}
+	})
+
+	Convey("scoring", t, func() {
+		b := NewBoard(StandardLayout)
+		So(b.ScoreAcross(EnglishTiles, 3, 7, "QUANT"), ShouldEqual, 48)
+		So(b.ScoreAcross(WordsWithFriendsTiles, 3, 7, "QUANT"), ShouldEqual, 52)
+
+		b.PlaceAcross(3, 7, "QUANT")
+		So(b.SidePoints(EnglishTiles, 4, 8, 'S'), ShouldEqual, 1)
+		So(b.SidePoints(WordsWithFriendsTiles, 4, 8, 'S'), ShouldEqual, 2)
+
+		// A bingo on the DW at 3, 7: 12 doubled and the 35 Words With
+		// Friends gives, or 11 doubled and 50 with the standard tiles.
+		b = NewBoard(WordsWithFriendsLayout)
+		So(b.ScoreAcross(WordsWithFriendsTiles, 1, 7, "OUTDREW"), ShouldEqual, 12*2+35)
+		So(b.ScoreAcross(EnglishTiles, 1, 7, "OUTDREW"), ShouldEqual, 11*2+50)
+		So(b.ScoreAcross(WordsWithFriendsTiles, 1, 7, "OUTDRE"), ShouldEqual, 8*2)
+
+		b = NewBoard(StandardLayout)
+		w := SpanishTiles.Alphabet.MustEncode("CHICO")
+		// CH I C O on 7, 7 to 10, 7: 5 + 1 + 3 + 1, doubled.
+		So(b.ScoreAcross(SpanishTiles, 7, 7, w), ShouldEqual, 20)
+	})
+}
+
+func TestBlanks(t *testing.T) {
+	Convey("tiles", t, func() {
+		b := Blank('E')
+		So(IsBlank(b), ShouldBeTrue)
+		So(IsBlank('E'), ShouldBeFalse)
+		So(Letter(b), ShouldEqual, 'E')
+		So(Letter('E'), ShouldEqual, 'E')
+		So(EnglishTiles.Value(b), ShouldEqual, 0)
+		So(EnglishTiles.Value('E'), ShouldEqual, 1)
+
+		ch := []rune(Spanish.MustEncode("CH"))[0]
+		So(Letter(Blank(ch)), ShouldEqual, ch)
+	})
+
+	Convey("encoding", t, func() {
+		tiles, err := English.EncodeTiles("OUTgREW")
+		So(err, ShouldBeNil)
+		So(tiles, ShouldEqual, "OUT"+string(Blank('G'))+"REW")
+		So(English.Decode(tiles), ShouldEqual, "OUTgREW")
+
+		// Words in a dictionary can't have blanks.
+		_, err = English.Encode("OUTgREW")
+		So(err, ShouldNotBeNil)
+
+		ch := []rune(Spanish.MustEncode("CH"))[0]
+		tiles, err = Spanish.EncodeTiles("chICO")
+		So(err, ShouldBeNil)
+		So([]rune(tiles)[0], ShouldEqual, Blank(ch))
+		So(Spanish.Decode(tiles), ShouldEqual, "chICO")
+		tiles, err = Spanish.EncodeTiles("[ch]ICO")
+		So(err, ShouldBeNil)
+		So([]rune(tiles)[0], ShouldEqual, Blank(ch))
+
+		e := parseLine(">Ana: ?ACEIOT 8H chICO +16 16", Spanish)
+		So(e.word, ShouldEqual, string(Blank(ch))+"ICO")
+	})
+
+	Convey("on the board", t, func() {
+		d := NewDAWG()
+		for _, w := range []string{"OUTGREW", "GO", "AG"} {
+			d.Add(w)
+		}
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(4, 7, English.MustEncode("OUT")+string(Blank('G'))+"REW")
+
+		So(b.Rows[7].String(), ShouldEqual, "    OUTgREW    ")
+
+		// The blank's letter makes GO down, not a word with a blank.
+		So(b.CrossChecks(7, 8, d), ShouldResemble, map[rune]bool{'O': true})
+		So(b.CrossChecks(7, 6, d), ShouldResemble, map[rune]bool{'A': true})
+
+		// Playing O under the blank scores for the O alone: the blank
+		// is worth nothing but still forms GO.
+		So(b.ScoreDown(EnglishTiles, 7, 7, string(Blank('G'))+"O"), ShouldEqual, 1)
+
+		// A cross word still counts when the rest of it is a blank.
+		b2 := NewBoard(StandardLayout)
+		b2.PlaceAcross(7, 7, string(Blank('A')))
+		// OX, with the X on a double letter, plus AO for the O.
+		So(b2.ScoreAcross(EnglishTiles, 7, 8, "OX"), ShouldEqual, 1+16+1)
+
+		// A word is read through the blank.
+		g := BuildGADDAG([]string{"CAT"})
+		d.Add("CAT")
+		want := map[moveKey]bool{{6, 7, Across, "C" + string(Blank('A')) + "T"}: true}
+		So(playSet(b2.GenerateRowMoves(7, Rack{'C': 1, 'T': 1}, d)), ShouldResemble, want)
+		So(playSet(b2.GenerateRowMovesGADDAG(7, Rack{'C': 1, 'T': 1}, g)), ShouldResemble, want)
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Tracer is told about each step of a search for moves, so that the
+// search can be followed when debugging it. Coordinates are those of
+// the board being searched, which for plays down is the transposed
+// board. Set Board.Tracer to use one; a nil Tracer costs nothing.
+type Tracer interface {
+	// Anchor is called as the search starts from the anchor square
+	// at x, y.
+	Anchor(x, y int)
+	// Extend is called as the search reaches a node of the lexicon
+	// with word placed so far, next to the square x, y.
+	Extend(x, y int, word string)
+	// CrossChecks is called with the letters that may go at x, y.
+	CrossChecks(x, y int, allowed map[rune]bool)
+	// Move is called with each play found.
+	Move(m Move)
+}
+
+// TreeTracer is a Tracer that writes the search to w as a tree, with
+// each step indented by the number of tiles placed so far.
+type TreeTracer struct {
+	w     io.Writer
+	a     *Alphabet
+	depth int
+}
+
+// NewTreeTracer returns a TreeTracer writing to w, spelling tiles with
+// the letters they have in a, which may be nil; see Row.Spell.
+func NewTreeTracer(w io.Writer, a *Alphabet) *TreeTracer {
+	return &TreeTracer{w: w, a: a}
+}
+
+func (t *TreeTracer) Anchor(x, y int) {
+	t.depth = 0
+	fmt.Fprintf(t.w, "anchor %d,%d\n", x, y)
+}
+
+func (t *TreeTracer) Extend(x, y int, word string) {
+	t.depth = utf8.RuneCountInString(word)
+	t.printf("%d,%d %s", x, y, Row(word).Spell(t.a))
+}
+
+func (t *TreeTracer) CrossChecks(x, y int, allowed map[rune]bool) {
+	letters := Row{}
+	for r, ok := range allowed {
+		if ok {
+			letters = append(letters, r)
+		}
+	}
+	slices.Sort(letters)
+	t.printf("cross checks %d,%d: %s", x, y, letters.Spell(t.a))
+}
+
+func (t *TreeTracer) Move(m Move) {
+	t.printf("move %d,%d %v %s", m.X, m.Y, m.Dir, Row(m.Word).Spell(t.a))
+}
+
+// printf writes a line of the tree below the current step.
+func (t *TreeTracer) printf(format string, args ...any) {
+	fmt.Fprintf(t.w, "%s"+format+"\n", append([]any{strings.Repeat("  ", t.depth+1)}, args...)...)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// countingTracer counts the calls made to it.
+type countingTracer struct {
+	anchors, extends, crossChecks int
+	moves                         []Move
+}
+
+func (c *countingTracer) Anchor(x, y int)                             { c.anchors++ }
+func (c *countingTracer) Extend(x, y int, word string)                { c.extends++ }
+func (c *countingTracer) CrossChecks(x, y int, allowed map[rune]bool) { c.crossChecks++ }
+func (c *countingTracer) Move(m Move)                                 { c.moves = append(c.moves, m) }
+
+func TestTracer(t *testing.T) {
+	d := NewDAWG()
+	for _, w := range []string{"OF", "OOF", "FOOL", "FOOD"} {
+		d.Add(w)
+	}
+	g := BuildGADDAG([]string{"OF", "OOF", "FOOL", "FOOD"})
+	ra := Rack{'F': 1, 'O': 2, 'D': 1, 'L': 1}
+
+	Convey("every step is traced", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(0, 0, "F")
+		want := playSet(b.GenerateRowMoves(0, ra, d))
+
+		c := &countingTracer{}
+		b.Tracer = c
+		So(playSet(b.GenerateRowMoves(0, ra, d)), ShouldResemble, want)
+		So(c.anchors, ShouldEqual, len(b.Anchors(0)))
+		So(c.extends, ShouldBeGreaterThan, 0)
+		So(c.crossChecks, ShouldBeGreaterThan, 0)
+		So(playSet(moveChan(c.moves)), ShouldResemble, want)
+
+		c = &countingTracer{}
+		b.Tracer = c
+		So(playSet(b.GenerateRowMovesGADDAG(0, ra, g)), ShouldResemble, want)
+		So(c.anchors, ShouldEqual, len(b.Anchors(0)))
+		So(playSet(moveChan(c.moves)), ShouldResemble, want)
+	})
+
+	Convey("the tracer is kept for plays down", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "F")
+		c := &countingTracer{}
+		b.Tracer = c
+		moves := GenerateMoves(b, ra, d, EnglishTiles)
+		So(len(c.moves), ShouldBeGreaterThanOrEqualTo, len(moves))
+	})
+
+	Convey("tree", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(0, 0, "F")
+		var buf bytes.Buffer
+		b.Tracer = NewTreeTracer(&buf, nil)
+		for range b.GenerateRowMoves(0, Rack{'O': 2, 'D': 1}, d) {
+		}
+		So(buf.String(), ShouldEqual, strings.Join([]string{
+			"anchor 1,0",
+			"    1,0 F",
+			"    cross checks 1,0: ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+			"      2,0 FO",
+			"      cross checks 2,0: ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+			"        3,0 FOO",
+			"        cross checks 3,0: ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+			"          4,0 FOOD",
+			"          move 0,0 across FOOD",
+			"          cross checks 4,0: ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		}, "\n")+"\n")
+	})
+}
+
+// moveChan returns a closed channel holding moves.
+func moveChan(moves []Move) chan Move {
+	ret := make(chan Move, len(moves))
+	for _, m := range moves {
+		ret <- m
+	}
+	close(ret)
+	return ret
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Direction is the way a play runs across the board.
+type Direction int
+
+const (
+	Across Direction = iota
+	Down
+)
+
+func (d Direction) String() string {
+	if d == Down {
+		return "down"
+	}
+	return "across"
+}
+
+// PlayedThrough may be given in a play's word in place of a tile
+// already on the board, as in GCG files.
+const PlayedThrough = '.'
+
+// The reasons ValidatePlay gives for a play being illegal.
+var (
+	ErrOffBoard     = errors.New("off the board")
+	ErrConflict     = errors.New("square already has a different tile")
+	ErrGap          = errors.New("leaves an empty square")
+	ErrNoTiles      = errors.New("plays no tiles")
+	ErrNotInRack    = errors.New("tile not in rack")
+	ErrNotConnected = errors.New("doesn't touch any tile on the board")
+	ErrMissesStart  = errors.New("first play doesn't cover the start square")
+	ErrNotAWord     = errors.New("not a word")
+)
+
+// PlayError is one reason a play is illegal. Err is one of the errors
+// above, X and Y are the square it concerns and Word is the word
+// rejected for ErrNotAWord.
+type PlayError struct {
+	Err  error
+	X, Y int
+	Word string
+}
+
+func (e *PlayError) Error() string {
+	if e.Word != "" {
+		return fmt.Sprintf("%q: %v", e.Word, e.Err)
+	}
+	return fmt.Sprintf("%d, %d: %v", e.X, e.Y, e.Err)
+}
+
+func (e *PlayError) Unwrap() error {
+	return e.Err
+}
+
+// PlayErrors is every reason a play is illegal. errors.Is and
+// errors.As see each of them.
+type PlayErrors []*PlayError
+
+func (e PlayErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, pe := range e {
+		msgs[i] = pe.Error()
+	}
+	return "illegal play: " + strings.Join(msgs, "; ")
+}
+
+func (e PlayErrors) Unwrap() []error {
+	ret := make([]error, len(e))
+	for i, pe := range e {
+		ret[i] = pe
+	}
+	return ret
+}
+
+// ValidatePlay checks whether playing word from x, y in direction dir
+// with the tiles on ra is legal, returning PlayErrors listing every
+// reason it isn't, or nil if it is. word has a tile for every square
+// the play covers, including those already on the board, which may
+// instead be given as PlayedThrough. Every word the play forms, across
+// and down, must be accepted by j.
+func (b *Board) ValidatePlay(ra Rack, x, y int, dir Direction, word string, j Judge) error {
+	if dir == Down {
+		err := b.Transpose().ValidatePlay(ra, y, x, Across, word, j)
+		if errs, ok := err.(PlayErrors); ok {
+			for _, e := range errs {
+				e.X, e.Y = e.Y, e.X
+			}
+		}
+		return err
+	}
+
+	var errs PlayErrors
+	fail := func(err error, x, y int, w string) {
+		errs = append(errs, &PlayError{err, x, y, w})
+	}
+
+	tiles := []rune(word)
+	if y < 0 || y >= len(b.Rows) || x < 0 || x+len(tiles) > len(b.Rows[y]) || len(tiles) == 0 {
+		fail(ErrOffBoard, x, y, "")
+		return errs
+	}
+	row := b.Rows[y]
+
+	// Work out what ends up on each square, and which tiles are new.
+	placed := make([]rune, len(tiles))
+	played := []int{}
+	need := Rack{}
+	for i, t := range tiles {
+		switch on := row[x+i]; {
+		case on == Empty && t == PlayedThrough:
+			fail(ErrGap, x+i, y, "")
+		case on == Empty:
+			placed[i] = t
+			played = append(played, i)
+			// Blanks come off the rack as Empty.
+			from := t
+			if IsBlank(t) {
+				from = Empty
+			}
+			need[from]++
+			if need[from] > ra[from] {
+				fail(ErrNotInRack, x+i, y, "")
+			}
+		case t != PlayedThrough && Letter(t) != Letter(on):
+			fail(ErrConflict, x+i, y, "")
+			placed[i] = on
+		default:
+			placed[i] = on
+		}
+	}
+	if len(played) == 0 {
+		fail(ErrNoTiles, x, y, "")
+	}
+
+	words, touches := b.formedWords(x, y, placed, played)
+	if b.isEmpty() {
+		sx, sy := b.Layout.Start()
+		if sy != y || sx < x || sx >= x+len(tiles) {
+			fail(ErrMissesStart, sx, sy, "")
+		}
+	} else if !touches {
+		fail(ErrNotConnected, x, y, "")
+	}
+
+	for _, w := range words {
+		s := letterString(w)
+		if strings.ContainsRune(s, Empty) {
+			// Already reported as a gap.
+			continue
+		}
+		if !j.Contains(s) {
+			fail(ErrNotAWord, x, y, s)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// formedWords returns the words made by putting placed on the squares
+// across from x, y, where played are the indexes of the new tiles: the
+// main word, which runs on past placed to any tiles either side, and
+// then the word down through each new tile. touches is true if any of
+// them take in a tile already on the board.
+func (b *Board) formedWords(x, y int, placed []rune, played []int) (words [][]rune, touches bool) {
+	row := b.Rows[y]
+	start, end := x, x+len(placed)
+	for start > 0 && row[start-1] != Empty {
+		start--
+	}
+	for end < len(row) && row[end] != Empty {
+		end++
+	}
+	main := []rune{}
+	for i := start; i < end; i++ {
+		if i >= x && i < x+len(placed) {
+			main = append(main, placed[i-x])
+		} else {
+			main = append(main, row[i])
+		}
+		touches = touches || row[i] != Empty
+	}
+
+	if len(main) > 1 {
+		words = append(words, main)
+	}
+	for _, i := range played {
+		if cross := b.crossWord(x+i, y, placed[i]); cross != nil {
+			words = append(words, cross)
+			touches = true
+		}
+	}
+	if len(played) > 0 && len(words) == 0 {
+		// A single tile on its own.
+		words = append(words, main)
+	}
+	return words, touches
+}
+
+// crossWord returns the word formed down through x, y with t placed
+// there, or nil if there are no tiles above or below.
+func (b *Board) crossWord(x, y int, t rune) []rune {
+	if !b.touchesDown(x, y) {
+		return nil
+	}
+	start, end := y, y
+	for start > 0 && b.Rows[start-1][x] != Empty {
+		start--
+	}
+	for end < len(b.Rows)-1 && b.Rows[end+1][x] != Empty {
+		end++
+	}
+	ret := []rune{}
+	for i := start; i <= end; i++ {
+		if i == y {
+			ret = append(ret, t)
+		} else {
+			ret = append(ret, b.Rows[i][x])
+		}
+	}
+	return ret
+}
+
+// isEmpty returns true if no tiles have been played on b.
+func (b *Board) isEmpty() bool {
+	for _, row := range b.Rows {
+		for _, t := range row {
+			if t != Empty {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// letterString returns the word spelled by tiles, with blanks as the
+// letters they were played as.
+func letterString(tiles []rune) string {
+	ret := make([]rune, len(tiles))
+	for i, t := range tiles {
+		ret[i] = Letter(t)
+	}
+	return string(ret)
+}
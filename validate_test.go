@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// playErrors returns the reasons err gives for a play being illegal.
+func playErrors(err error) []error {
+	var errs PlayErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	ret := []error{}
+	for _, e := range errs {
+		ret = append(ret, e.Err)
+	}
+	return ret
+}
+
+func TestValidatePlay(t *testing.T) {
+	j := testJudge{}
+	for _, w := range []string{"CAT", "CATS", "AT", "TA", "SAT", "ACT", "SCAT", "AS", "TAS"} {
+		j[w] = true
+	}
+	ra := Rack{'A': 1, 'C': 1, 'S': 1, 'T': 1, Empty: 1}
+
+	Convey("first play", t, func() {
+		b := NewBoard(StandardLayout)
+		So(b.ValidatePlay(ra, 7, 7, Across, "CAT", j), ShouldBeNil)
+		So(b.ValidatePlay(ra, 7, 5, Down, "CAT", j), ShouldBeNil)
+		So(playErrors(b.ValidatePlay(ra, 0, 0, Across, "CAT", j)), ShouldResemble, []error{ErrMissesStart})
+		So(playErrors(b.ValidatePlay(ra, 7, 7, Across, "A", j)), ShouldResemble, []error{ErrNotAWord})
+		So(playErrors(b.ValidatePlay(ra, 7, 7, Across, "TAC", j)), ShouldResemble, []error{ErrNotAWord})
+	})
+
+	Convey("off the board", t, func() {
+		b := NewBoard(StandardLayout)
+		So(playErrors(b.ValidatePlay(ra, 13, 7, Across, "CAT", j)), ShouldResemble, []error{ErrOffBoard})
+		So(playErrors(b.ValidatePlay(ra, 7, 13, Down, "CAT", j)), ShouldResemble, []error{ErrOffBoard})
+		So(playErrors(b.ValidatePlay(ra, -1, 7, Across, "CAT", j)), ShouldResemble, []error{ErrOffBoard})
+		So(playErrors(b.ValidatePlay(ra, 7, 15, Across, "CAT", j)), ShouldResemble, []error{ErrOffBoard})
+		So(playErrors(b.ValidatePlay(ra, 7, 7, Across, "", j)), ShouldResemble, []error{ErrOffBoard})
+	})
+
+	Convey("rack", t, func() {
+		b := NewBoard(StandardLayout)
+		err := b.ValidatePlay(Rack{'C': 1, 'A': 1}, 7, 7, Across, "CAT", j)
+		So(errors.Is(err, ErrNotInRack), ShouldBeTrue)
+		var pe *PlayError
+		So(errors.As(err, &pe), ShouldBeTrue)
+		So(pe.X, ShouldEqual, 9)
+		So(pe.Y, ShouldEqual, 7)
+
+		// A blank can stand for the T, but only one.
+		So(b.ValidatePlay(Rack{'C': 1, 'A': 1, Empty: 1}, 7, 7, Across, "CA"+string(Blank('T')), j), ShouldBeNil)
+		So(playErrors(b.ValidatePlay(Rack{'C': 1, Empty: 1}, 7, 7, Across, "C"+string(Blank('A'))+string(Blank('T')), j)), ShouldResemble, []error{ErrNotInRack})
+		So(playErrors(b.ValidatePlay(Rack{'A': 2}, 7, 7, Across, "AA", testJudge{"AA": true})), ShouldBeNil)
+	})
+
+	Convey("later plays", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "CAT")
+
+		// Extending and playing through tiles.
+		So(b.ValidatePlay(ra, 7, 7, Across, "CATS", j), ShouldBeNil)
+		So(b.ValidatePlay(ra, 6, 7, Across, "S...", j), ShouldBeNil)
+		So(b.ValidatePlay(ra, 8, 6, Down, "TA", j), ShouldBeNil)
+		So(b.ValidatePlay(ra, 8, 7, Down, ".S", j), ShouldBeNil)
+
+		// The play runs on into the tiles after it.
+		So(b.ValidatePlay(ra, 6, 7, Across, "S", j), ShouldBeNil)
+		err := b.ValidatePlay(ra, 5, 7, Across, "SA", j)
+		So(playErrors(err), ShouldResemble, []error{ErrNotAWord})
+		So(err.(PlayErrors)[0].Word, ShouldEqual, "SACAT")
+
+		So(playErrors(b.ValidatePlay(ra, 7, 7, Across, "CAT", j)), ShouldResemble, []error{ErrNoTiles})
+		So(playErrors(b.ValidatePlay(ra, 7, 7, Across, "ACTS", j)), ShouldResemble, []error{ErrConflict, ErrConflict})
+		So(playErrors(b.ValidatePlay(ra, 0, 0, Across, "AT", j)), ShouldResemble, []error{ErrNotConnected})
+		So(playErrors(b.ValidatePlay(ra, 9, 5, Down, "A.T", j)), ShouldResemble, []error{ErrGap})
+		So(playErrors(b.ValidatePlay(ra, 7, 7, Across, "...S.", j)), ShouldResemble, []error{ErrGap})
+	})
+
+	Convey("cross words", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "CAT")
+
+		// AS across under CA makes CA and AS down too, but only AS
+		// is a word.
+		err := b.ValidatePlay(ra, 7, 8, Across, "AS", j)
+		So(playErrors(err), ShouldResemble, []error{ErrNotAWord})
+		var pe *PlayError
+		So(errors.As(err, &pe), ShouldBeTrue)
+		So(pe.Word, ShouldEqual, "CA")
+
+		j["CA"] = true
+		So(b.ValidatePlay(ra, 7, 8, Across, "AS", j), ShouldBeNil)
+
+		// Blanks in cross words are their letters.
+		b.PlaceAcross(7, 8, "A"+string(Blank('S')))
+		So(b.ValidatePlay(ra, 9, 8, Across, "T", j), ShouldNotBeNil)
+		j["AST"] = true
+		j["TT"] = true
+		So(b.ValidatePlay(ra, 9, 8, Across, "T", j), ShouldBeNil)
+	})
+
+	Convey("every reason is given", t, func() {
+		b := NewBoard(StandardLayout)
+		b.PlaceAcross(7, 7, "CAT")
+		err := b.ValidatePlay(Rack{}, 0, 0, Down, "ZZ", j)
+		So(playErrors(err), ShouldResemble, []error{ErrNotInRack, ErrNotInRack, ErrNotConnected, ErrNotAWord})
+		So(err.Error(), ShouldEqual, `illegal play: 0, 0: tile not in rack; 0, 1: tile not in rack; 0, 0: doesn't touch any tile on the board; "ZZ": not a word`)
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+import "iter"
+
+// Words yields every word accepted by d in lexical order.
+func (d *DAWG) Words() iter.Seq[string] {
+	return d.WordsWithPrefix("")
+}
+
+// WordsWithPrefix yields every word accepted by d that starts with
+// prefix, in lexical order.
+func (d *DAWG) WordsWithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		node := d
+		for _, r := range prefix {
+			if node = node.Edge[r]; node == nil {
+				return
+			}
+		}
+		node.words([]rune(prefix), yield)
+	}
+}
+
+// words calls yield with prefix followed by each suffix accepted from
+// d, stopping and returning false as soon as yield does.
+func (d *DAWG) words(prefix []rune, yield func(string) bool) bool {
+	if d.Terminal && !yield(string(prefix)) {
+		return false
+	}
+	for _, r := range sortedEdges(d) {
+		if !d.Edge[r].words(append(prefix, r), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of words accepted by d. Counts are cached
+// in each node, so they're only worked out once for a given graph.
+func (d *DAWG) Count() int {
+	if d.count == 0 {
+		for _, next := range d.Edge {
+			d.count += next.Count()
+		}
+		if d.Terminal {
+			d.count++
+		}
+	}
+	return d.count
+}
+
+// Stats summarizes the size and shape of a graph.
+type Stats struct {
+	Words int
+	Nodes int
+	Edges int
+	// Lengths[n] is the number of words n runes long.
+	Lengths []int
+}
+
+// Stats returns statistics about the words and nodes in d.
+func (d *DAWG) Stats() Stats {
+	s := Stats{Nodes: 1, Edges: len(d.Edge)}
+	Visitor{}.Traverse(d, func(e rune, g *DAWG) {
+		s.Nodes++
+		s.Edges += len(g.Edge)
+	})
+
+	// Count words by length a level at a time, so shared nodes are
+	// only visited once per depth rather than once per path.
+	level := map[*DAWG]int{d: 1}
+	for depth := 0; len(level) > 0; depth++ {
+		next := map[*DAWG]int{}
+		for node, paths := range level {
+			if node.Terminal {
+				for len(s.Lengths) <= depth {
+					s.Lengths = append(s.Lengths, 0)
+				}
+				s.Lengths[depth] += paths
+				s.Words += paths
+			}
+			for _, child := range node.Edge {
+				next[child] += paths
+			}
+		}
+		level = next
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWords(t *testing.T) {
+	words := slices.Clone(append(gameWords, testWords...))
+	slices.Sort(words)
+	words = slices.Compact(words)
+
+	d := NewDAWG()
+	for _, w := range words {
+		d.Add(w)
+	}
+	d.Minimize()
+
+	Convey("all words in order", t, func() {
+		So(slices.Collect(d.Words()), ShouldResemble, words)
+		So(d.Count(), ShouldEqual, len(words))
+	})
+
+	Convey("with prefix", t, func() {
+		So(slices.Collect(d.WordsWithPrefix("CART")), ShouldResemble, []string{"CART", "CARTS"})
+		So(slices.Collect(d.WordsWithPrefix("TON")), ShouldResemble, []string{"TON", "TONE", "TONER"})
+		So(slices.Collect(d.WordsWithPrefix("QQ")), ShouldBeEmpty)
+		So(slices.Collect(d.WordsWithPrefix("")), ShouldResemble, words)
+	})
+
+	Convey("stopping early", t, func() {
+		got := []string{}
+		for w := range d.Words() {
+			got = append(got, w)
+			if len(got) == 5 {
+				break
+			}
+		}
+		So(got, ShouldResemble, words[:5])
+	})
+
+	Convey("stats", t, func() {
+		s := d.Stats()
+		So(s.Words, ShouldEqual, len(words))
+		So(s.Nodes, ShouldEqual, d.NodeCount())
+		p, err := d.Pack()
+		So(err, ShouldBeNil)
+		So(s.Edges, ShouldEqual, len(p.Edges)-1)
+
+		lengths := make([]int, len(s.Lengths))
+		for _, w := range words {
+			lengths[len(w)]++
+		}
+		So(s.Lengths, ShouldResemble, lengths)
+	})
+
+	Convey("empty", t, func() {
+		d := NewDAWG()
+		So(slices.Collect(d.Words()), ShouldBeEmpty)
+		So(d.Count(), ShouldEqual, 0)
+		So(d.Stats(), ShouldResemble, Stats{Nodes: 1})
+	})
+}